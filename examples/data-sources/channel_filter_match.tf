@@ -0,0 +1,11 @@
+data "ably_channel_filter_match" "rule0" {
+  channel_filter = ably_rule_http.rule0.source.channel_filter
+  channels       = ["my-channel", "my-channel:updates", "private:my-channel"]
+}
+
+check "rule0_channels" {
+  assert {
+    condition     = data.ably_channel_filter_match.rule0.matching_channels == tolist(["my-channel", "my-channel:updates"])
+    error_message = "rule0 does not capture the expected channels: ${join(", ", data.ably_channel_filter_match.rule0.matching_channels)}"
+  }
+}
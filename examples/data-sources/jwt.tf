@@ -0,0 +1,12 @@
+data "ably_jwt" "smoke_test" {
+  key              = ably_api_key.api_key_1.key
+  key_capabilities = ably_api_key.api_key_1.capabilities
+  client_id        = "smoke-test"
+  ttl              = "30m"
+  capabilities = {
+    "channel1" = ["subscribe"]
+  }
+  claims = {
+    "environment" = "staging"
+  }
+}
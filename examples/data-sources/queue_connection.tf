@@ -0,0 +1,13 @@
+resource "ably_api_key" "queue_consumer" {
+  app_id = ably_app.app1.id
+  name   = "queue consumer"
+  capabilities = {
+    "[queue]*" = ["subscribe"]
+  }
+}
+
+data "ably_queue_connection" "example_queue" {
+  app_id   = ably_app.app1.id
+  queue_id = ably_queue.example_queue.id
+  key      = ably_api_key.queue_consumer.key
+}
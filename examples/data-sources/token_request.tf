@@ -0,0 +1,9 @@
+data "ably_token_request" "bootstrap" {
+  key              = ably_api_key.api_key_1.key
+  key_capabilities = ably_api_key.api_key_1.capabilities
+  client_id        = "bootstrap"
+  ttl              = "1h"
+  capabilities = {
+    "channel1" = ["publish", "subscribe"]
+  }
+}
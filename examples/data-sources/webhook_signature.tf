@@ -0,0 +1,22 @@
+data "ably_webhook_signature" "fixture" {
+  key = ably_api_key.api_key_0.key
+  payload = jsonencode({
+    items = [
+      {
+        name = "channel.message"
+        data = { channelId = "my-channel", messages = [] }
+      }
+    ]
+  })
+}
+
+resource "local_file" "webhook_fixture" {
+  filename = "fixtures/webhook.json"
+  content = jsonencode({
+    headers = {
+      "X-Ably-Key"       = data.ably_webhook_signature.fixture.key_name
+      "X-Ably-Signature" = data.ably_webhook_signature.fixture.signature
+    }
+    body = data.ably_webhook_signature.fixture.payload
+  })
+}
@@ -0,0 +1,3 @@
+output "capability" {
+  value = provider::ably::capability_json(ably_api_key.api_key_1.capabilities)
+}
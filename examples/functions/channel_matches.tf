@@ -0,0 +1,6 @@
+check "rule0_channels" {
+  assert {
+    condition     = provider::ably::channel_matches(ably_rule_http.rule0.source.channel_filter, "my-channel:updates")
+    error_message = "rule0 does not capture my-channel:updates"
+  }
+}
@@ -0,0 +1,4 @@
+resource "ably_namespace" "chat" {
+  app_id = ably_app.app0.id
+  id     = provider::ably::namespace_of("chat:room:1")
+}
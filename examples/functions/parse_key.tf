@@ -0,0 +1,7 @@
+locals {
+  key_parts = provider::ably::parse_key(ably_api_key.api_key_1.key)
+}
+
+output "key_name" {
+  value = local.key_parts.key_name
+}
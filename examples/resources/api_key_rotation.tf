@@ -0,0 +1,14 @@
+resource "ably_api_key_rotation" "rotation0" {
+  app_id = ably_app.app1.id
+  name   = "backend"
+  capabilities = {
+    "channel1" = ["publish", "subscribe"],
+  }
+  overlap = "24h"
+
+  # Changing a trigger value creates a new key. The replaced key stays valid
+  # as previous_key until the overlap has elapsed.
+  rotation_triggers = {
+    rotated_on = "2024-01-01"
+  }
+}
@@ -0,0 +1,15 @@
+resource "ably_app_namespaces" "namespaces0" {
+  app_id = ably_app.app0.id
+
+  namespaces = {
+    "public" = {
+      authenticated = false
+      persisted     = true
+    }
+    "private" = {
+      authenticated     = true
+      tls_only          = true
+      expose_timeserial = true
+    }
+  }
+}
@@ -0,0 +1,15 @@
+resource "ably_app_push_credentials" "push0" {
+  app_id                    = ably_app.app0.id
+  fcm_service_account       = file("firebase-service-account.json")
+  fcm_project_id            = "ably-tf-provider-project"
+  apns_certificate          = file("apns-certificate.pem")
+  apns_private_key          = file("apns-private-key.pem")
+  apns_use_sandbox_endpoint = false
+
+  # Change this value to write the credentials to the app again.
+  rotation_trigger = "2024-01"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -7,3 +7,18 @@ resource "ably_api_key" "api_key_1" {
     "channel3" = ["subscribe"],
   }
 }
+
+# Capabilities can also be set with capability blocks, which are easier to
+# compose with dynamic blocks
+resource "ably_api_key" "api_key_2" {
+  app_id = ably_app.app1.id
+  name   = "key-0002"
+
+  dynamic "capability" {
+    for_each = toset(["channel1", "channel2"])
+    content {
+      resource   = capability.value
+      operations = ["publish", "subscribe"]
+    }
+  }
+}
@@ -19,6 +19,15 @@ resource "ably_namespace" "namespace_batching" {
   tls_only          = false
   expose_timeserial = false
   batching_enabled  = true
-  batching_policy   = "some-policy"
+  batching_policy   = "simple"
   batching_interval = 100
 }
+
+resource "ably_namespace" "namespace_conflation" {
+  app_id                    = ably_app.app0.id
+  id                        = "conflated"
+  conflation_enabled        = true
+  conflation_interval       = 1000
+  conflation_key            = "#{message.name}"
+  populate_channel_registry = true
+}
@@ -17,6 +17,12 @@ resource "ably_rule_http" "rule0" {
         value = "headerValue"
       }
     ]
+    sensitive_headers = [
+      {
+        name  = "Authorization"
+        value = "Bearer secret-token"
+      }
+    ]
     signing_key_id = ably_api_key.api_key_0.id
     enveloped      = true
     format         = "json"
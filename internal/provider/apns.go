@@ -0,0 +1,288 @@
+package ably_control
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Certificate extensions Apple adds to push certificates for the sandbox (development)
+// and production environments.
+var (
+	apnsSandboxOID    = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 3, 1}
+	apnsProductionOID = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 3, 2}
+	apnsTopicsOID     = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 3, 6}
+)
+
+// Certificates expiring within this window produce a warning at plan time.
+const apnsCertificateExpiryWarning = 30 * 24 * time.Hour
+
+// An Apple Push Notification service certificate and the environments it can be used with.
+type apnsCertificate struct {
+	Certificate *x509.Certificate
+	Sandbox     bool
+	Production  bool
+}
+
+// Parses a PEM encoded APNs certificate, and its private key when given, checking that
+// the key matches the certificate and that the certificate was issued for Apple Push.
+func parseApnsCertificate(cert_pem string, key_pem string) (*apnsCertificate, error) {
+	if key_pem != "" {
+		if _, err := tls.X509KeyPair([]byte(cert_pem), []byte(key_pem)); err != nil {
+			return nil, err
+		}
+	}
+
+	certs, err := x509.ParseCertificates(pemBlocks(cert_pem, "CERTIFICATE"))
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found in PEM data")
+	}
+	leaf := certs[0]
+
+	apns := apnsCertificate{Certificate: leaf}
+	for _, ext := range leaf.Extensions {
+		switch {
+		case ext.Id.Equal(apnsSandboxOID):
+			apns.Sandbox = true
+		case ext.Id.Equal(apnsProductionOID):
+			apns.Production = true
+		case ext.Id.Equal(apnsTopicsOID):
+			apns.Sandbox = true
+			apns.Production = true
+		}
+	}
+
+	// Falls back to the common name for certificates without the Apple extensions
+	if !apns.Sandbox && !apns.Production {
+		cn := leaf.Subject.CommonName
+		if !strings.HasPrefix(cn, "Apple ") || !strings.Contains(cn, "Push Services") {
+			return nil, fmt.Errorf("certificate %q is not an Apple Push Notification service certificate", cn)
+		}
+		switch {
+		case strings.HasPrefix(cn, "Apple Development"), strings.HasPrefix(cn, "Apple Sandbox"):
+			apns.Sandbox = true
+		case strings.HasPrefix(cn, "Apple Production"):
+			apns.Production = true
+		default:
+			apns.Sandbox = true
+			apns.Production = true
+		}
+	}
+
+	return &apns, nil
+}
+
+// Returns the concatenated DER bytes of all PEM blocks of the given type.
+func pemBlocks(data string, block_type string) []byte {
+	var der []byte
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return der
+		}
+		if block.Type == block_type {
+			der = append(der, block.Bytes...)
+		}
+	}
+}
+
+// Decodes a base64 encoded PKCS#12 bundle into a PEM encoded certificate and private key.
+// Any CA certificates in the bundle are appended to the certificate after the leaf.
+func decodeApnsP12(p12_base64 string, password string) (string, string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(p12_base64))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid base64: %w", err)
+	}
+
+	private_key, cert, ca_certs, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return "", "", err
+	}
+
+	key_der, err := x509.MarshalPKCS8PrivateKey(private_key)
+	if err != nil {
+		return "", "", err
+	}
+
+	cert_pem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	for _, ca_cert := range ca_certs {
+		cert_pem = append(cert_pem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca_cert.Raw})...)
+	}
+	key_pem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key_der})
+	return string(cert_pem), string(key_pem), nil
+}
+
+// Gets the PEM encoded APNs certificate and private key, decoding them from the
+// PKCS#12 bundle when one is set.
+func GetApnsCredentials(cert types.String, key types.String, p12 types.String, password types.String) (types.String, types.String, error) {
+	if p12.IsNull() {
+		return cert, key, nil
+	}
+	if p12.IsUnknown() || password.IsUnknown() {
+		return types.StringUnknown(), types.StringUnknown(), nil
+	}
+
+	cert_pem, key_pem, err := decodeApnsP12(p12.ValueString(), password.ValueString())
+	if err != nil {
+		return types.StringNull(), types.StringNull(), err
+	}
+
+	return types.StringValue(cert_pem), types.StringValue(key_pem), nil
+}
+
+// Validates the APNs certificate and private key pair, or the PKCS#12 bundle containing
+// them, and that the certificate can be used with the configured endpoint.
+func ValidateApnsCredentials(cert types.String, key types.String, p12 types.String, password types.String, use_sandbox types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !p12.IsNull() && (!cert.IsNull() || !key.IsNull()) {
+		diags.AddAttributeError(
+			path.Root("apns_p12"),
+			"Invalid Attribute Combination",
+			"apns_p12 cannot be set together with apns_certificate or apns_private_key",
+		)
+		return diags
+	}
+	if p12.IsNull() && !password.IsNull() {
+		diags.AddAttributeError(
+			path.Root("apns_p12_password"),
+			"Invalid Attribute Combination",
+			"apns_p12_password can only be set when apns_p12 is set",
+		)
+		return diags
+	}
+
+	cert_name := "apns_certificate"
+	if !p12.IsNull() {
+		cert_name = "apns_p12"
+	}
+
+	if cert.IsUnknown() || key.IsUnknown() || use_sandbox.IsUnknown() {
+		return diags
+	}
+	if p12.IsNull() {
+		if cert.IsNull() && key.IsNull() {
+			return diags
+		}
+		if cert.IsNull() {
+			diags.AddAttributeError(
+				path.Root("apns_certificate"),
+				"Missing Attribute",
+				"apns_certificate must be set when apns_private_key is set",
+			)
+			return diags
+		}
+		if key.IsNull() {
+			diags.AddAttributeError(
+				path.Root("apns_private_key"),
+				"Missing Attribute",
+				"apns_private_key must be set when apns_certificate is set",
+			)
+			return diags
+		}
+	}
+
+	cert, key, err := GetApnsCredentials(cert, key, p12, password)
+	if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		diags.AddAttributeError(
+			path.Root("apns_p12_password"),
+			"Invalid Attribute Value",
+			"apns_p12_password is not the password of apns_p12",
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("apns_p12"),
+			"Invalid Attribute Value",
+			"apns_p12 must be a base64 encoded PKCS#12 bundle containing a certificate and its private key: "+err.Error(),
+		)
+		return diags
+	}
+	if cert.IsUnknown() {
+		return diags
+	}
+
+	apns, err := parseApnsCertificate(cert.ValueString(), key.ValueString())
+	if err != nil {
+		message := "apns_certificate and apns_private_key must be a matching PEM encoded Apple Push Notification service certificate and private key: "
+		if !p12.IsNull() {
+			message = "apns_p12 must contain an Apple Push Notification service certificate: "
+		}
+		diags.AddAttributeError(
+			path.Root(cert_name),
+			"Invalid Attribute Value",
+			message+err.Error(),
+		)
+		return diags
+	}
+
+	// The endpoint is only checked when it is set, as an unset endpoint keeps the current
+	// setting of the app which may be managed elsewhere.
+	sandbox := use_sandbox.ValueBool()
+	if !use_sandbox.IsNull() && sandbox && !apns.Sandbox {
+		diags.AddAttributeError(
+			path.Root("apns_use_sandbox_endpoint"),
+			"Invalid Attribute Value",
+			cert_name+" is a production certificate and cannot be used with the sandbox endpoint, set apns_use_sandbox_endpoint to false",
+		)
+	}
+	if !use_sandbox.IsNull() && !sandbox && !apns.Production {
+		diags.AddAttributeError(
+			path.Root("apns_use_sandbox_endpoint"),
+			"Invalid Attribute Value",
+			cert_name+" is a development certificate and can only be used with the sandbox endpoint, set apns_use_sandbox_endpoint to true",
+		)
+	}
+
+	expires_at := apns.Certificate.NotAfter
+	if time.Now().After(expires_at) {
+		diags.AddAttributeWarning(
+			path.Root(cert_name),
+			"APNs Certificate Expired",
+			fmt.Sprintf("%s expired at %s, push notifications to Apple devices will fail until it is replaced", cert_name, expires_at.UTC().Format(time.RFC3339)),
+		)
+	} else if time.Until(expires_at) < apnsCertificateExpiryWarning {
+		diags.AddAttributeWarning(
+			path.Root(cert_name),
+			"APNs Certificate Expiring",
+			fmt.Sprintf("%s expires at %s, replace it before then to avoid failed push notifications", cert_name, expires_at.UTC().Format(time.RFC3339)),
+		)
+	}
+
+	return diags
+}
+
+// Gets the expiry time and subject of the APNs certificate. Returns nulls if the
+// certificate is not set or cannot be parsed, and unknowns if it is unknown.
+func GetApnsCertificateDetails(cert types.String) (types.String, types.String) {
+	if cert.IsUnknown() {
+		return types.StringUnknown(), types.StringUnknown()
+	}
+	if cert.ValueString() == "" {
+		return types.StringNull(), types.StringNull()
+	}
+
+	apns, err := parseApnsCertificate(cert.ValueString(), "")
+	if err != nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	return types.StringValue(apns.Certificate.NotAfter.UTC().Format(time.RFC3339)), types.StringValue(apns.Certificate.Subject.String())
+}
@@ -0,0 +1,30 @@
+package ably_control
+
+import (
+	"encoding/json"
+
+	ably_control_go "github.com/ably/ably-control-go"
+)
+
+// Updates an app, leaving the fields with the given JSON names unchanged. The Client Library sends
+// every push credential field, which clears credentials written by the ably_app_push_credentials
+// resource, so the omitted fields are removed from the request body.
+func (p *provider) updateAppOmitting(app_id string, app *ably_control_go.NewApp, omit []string) (ably_control_go.App, error) {
+	var out ably_control_go.App
+
+	in, err := json.Marshal(app)
+	if err != nil {
+		return out, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(in, &fields); err != nil {
+		return out, err
+	}
+	for _, name := range omit {
+		delete(fields, name)
+	}
+
+	err = p.request("PATCH", "/apps/"+app_id, fields, &out)
+	return out, err
+}
@@ -0,0 +1,48 @@
+package ably_control
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ably_control_go "github.com/ably/ably-control-go"
+)
+
+func TestUpdateAppOmitting(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/apps/app0" || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Ably-Agent") != ablyAgent("test") {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"app0","name":"renamed","apnsUseSandboxEndpoint":true}`))
+	}))
+	defer server.Close()
+
+	p := &provider{client: &ably_control_go.Client{Url: server.URL}, token: "token", ably_agent: ablyAgent("test"), version: "test"}
+	app, err := p.updateAppOmitting("app0", &ably_control_go.NewApp{Name: "renamed", FcmKey: "key"}, []string{"apnsCertificate", "apnsPrivateKey", "apnsUseSandboxEndpoint"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Name != "renamed" || !app.ApnsUseSandboxEndpoint {
+		t.Errorf("unexpected app: %+v", app)
+	}
+
+	for _, name := range []string{"apnsCertificate", "apnsPrivateKey", "apnsUseSandboxEndpoint"} {
+		if _, ok := body[name]; ok {
+			t.Errorf("expected %s to be omitted from the request body: %v", name, body)
+		}
+	}
+	if body["name"] != "renamed" || body["fcmKey"] != "key" {
+		t.Errorf("unexpected request body: %v", body)
+	}
+
+	if _, err := p.updateAppOmitting("missing", &ably_control_go.NewApp{}, nil); !is_404(err) {
+		t.Errorf("expected a 404 error, got: %v", err)
+	}
+}
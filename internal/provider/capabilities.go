@@ -0,0 +1,237 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Operations which can be granted by a key capability. More information on capabilities can be found in
+// the Ably documentation https://ably.com/docs/auth/capabilities
+var keyCapabilityOperations = []string{
+	"*",
+	"channel-metadata",
+	"history",
+	"presence",
+	"privileged-headers",
+	"publish",
+	"push-admin",
+	"push-subscribe",
+	"stats",
+	"subscribe",
+}
+
+// Qualifiers which can prefix a capability resource, such as [queue]appid:name. Qualifiers
+// starting with ? hold channel params, such as [?rewind=1]channel.
+var keyCapabilityQualifiers = []string{"*", "meta", "queue"}
+
+// Gets the capabilities attribute schema.
+func GetKeyCapabilitiesSchema(description string) schema.Attribute {
+	return schema.MapAttribute{
+		ElementType: types.SetType{
+			ElemType: types.StringType,
+		},
+		Optional:    true,
+		Description: description + " Either capabilities or capability blocks must be set.",
+	}
+}
+
+// Gets the capability block schema, an alternative to the capabilities map which is easier to compose with dynamic blocks.
+func GetKeyCapabilityBlockSchema() schema.Block {
+	return schema.SetNestedBlock{
+		Description: "A capability granting operations on a resource. Either capabilities or capability blocks must be set.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"resource": schema.StringAttribute{
+					Required:    true,
+					Description: "The resource the operations are granted on, such as a channel name, a wildcard pattern like `chat:*` or a qualified resource like `[queue]*`.",
+				},
+				"operations": schema.SetAttribute{
+					ElementType: types.StringType,
+					Required:    true,
+					Description: fmt.Sprintf("The operations granted on the resource. Values are %s.", strings.Join(keyCapabilityOperations, ", ")),
+				},
+			},
+		},
+	}
+}
+
+// Checks a capability resource specifier.
+func validateCapabilityResource(p path.Path, resource string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if resource == "" || strings.TrimSpace(resource) != resource {
+		diags.AddAttributeError(
+			p,
+			"Invalid Capability Resource",
+			fmt.Sprintf("capability resource must be a non-empty name without leading or trailing whitespace, got: %q", resource),
+		)
+		return diags
+	}
+
+	if strings.HasPrefix(resource, "[") {
+		qualifier, _, found := strings.Cut(resource[1:], "]")
+		if !found || !(slices.Contains(keyCapabilityQualifiers, qualifier) || strings.HasPrefix(qualifier, "?")) {
+			diags.AddAttributeError(
+				p,
+				"Invalid Capability Resource",
+				fmt.Sprintf("capability resource qualifier must be one of [%s] or channel params starting with [?, got: %q", strings.Join(keyCapabilityQualifiers, "], ["), resource),
+			)
+		}
+	}
+
+	return diags
+}
+
+// Checks the operations of a capability.
+func validateCapabilityOperations(p path.Path, resource string, operations types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if operations.IsUnknown() {
+		return diags
+	}
+
+	if len(operations.Elements()) == 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Capability Operations",
+			fmt.Sprintf("at least one operation must be granted on %q", resource),
+		)
+	}
+
+	for _, v := range operations.Elements() {
+		op, ok := v.(types.String)
+		if !ok || op.IsUnknown() || op.IsNull() {
+			continue
+		}
+
+		if !slices.Contains(keyCapabilityOperations, op.ValueString()) {
+			diags.AddAttributeError(
+				p,
+				"Invalid Capability Operation",
+				fmt.Sprintf("operation on %q must be one of %s, got: %q", resource, strings.Join(keyCapabilityOperations, ", "), op.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// Validates the capabilities map and capability blocks of a key. Exactly one of the forms must be used.
+func ValidateKeyCapabilities(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var capabilities types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("capabilities"), &capabilities)...)
+
+	var blocks types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("capability"), &blocks)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if capabilities.IsUnknown() || blocks.IsUnknown() {
+		return diags
+	}
+
+	has_blocks := len(blocks.Elements()) > 0
+	if capabilities.IsNull() && !has_blocks {
+		diags.AddAttributeError(
+			path.Root("capabilities"),
+			"Missing Attribute",
+			"one of capabilities or capability blocks must be set",
+		)
+		return diags
+	}
+	if !capabilities.IsNull() && has_blocks {
+		diags.AddAttributeError(
+			path.Root("capabilities"),
+			"Invalid Attribute Combination",
+			"capabilities can not be set together with capability blocks",
+		)
+		return diags
+	}
+
+	for resource, v := range capabilities.Elements() {
+		p := path.Root("capabilities").AtMapKey(resource)
+		diags.Append(validateCapabilityResource(p, resource)...)
+		if operations, ok := v.(types.Set); ok {
+			diags.Append(validateCapabilityOperations(p, resource, operations)...)
+		}
+	}
+
+	resources := map[string]bool{}
+	for _, v := range blocks.Elements() {
+		block, ok := v.(types.Object)
+		if !ok || block.IsUnknown() {
+			continue
+		}
+		p := path.Root("capability").AtSetValue(block)
+
+		resource, _ := block.Attributes()["resource"].(types.String)
+		if resource.IsUnknown() {
+			continue
+		}
+		diags.Append(validateCapabilityResource(p.AtName("resource"), resource.ValueString())...)
+
+		if resources[resource.ValueString()] {
+			diags.AddAttributeError(
+				p.AtName("resource"),
+				"Duplicate Capability Resource",
+				fmt.Sprintf("capability resource %q is set in more than one capability block", resource.ValueString()),
+			)
+		}
+		resources[resource.ValueString()] = true
+
+		if operations, ok := block.Attributes()["operations"].(types.Set); ok {
+			diags.Append(validateCapabilityOperations(p.AtName("operations"), resource.ValueString(), operations)...)
+		}
+	}
+
+	return diags
+}
+
+// Gets the key capability to send to the Control API from either of the capability forms.
+func GetKeyCapabilities(capabilities map[string][]string, blocks []AblyKeyCapability) map[string][]string {
+	if len(blocks) == 0 {
+		return capabilities
+	}
+
+	capability := map[string][]string{}
+	for _, b := range blocks {
+		capability[b.Resource.ValueString()] = b.Operations
+	}
+
+	return capability
+}
+
+// Sets the key capability returned by the Control API in the capability form used by the prior state or plan.
+func SetKeyCapabilities(capability map[string][]string, capabilities *map[string][]string, blocks *[]AblyKeyCapability) {
+	if len(*blocks) == 0 {
+		*capabilities = capability
+		return
+	}
+
+	resources := make([]string, 0, len(capability))
+	for resource := range capability {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	*capabilities = nil
+	*blocks = make([]AblyKeyCapability, 0, len(resources))
+	for _, resource := range resources {
+		*blocks = append(*blocks, AblyKeyCapability{
+			Resource:   types.StringValue(resource),
+			Operations: capability[resource],
+		})
+	}
+}
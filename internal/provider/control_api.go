@@ -0,0 +1,59 @@
+package ably_control
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	ably_control_go "github.com/ably/ably-control-go"
+)
+
+// Sends a request to the Control API for the endpoints and fields which the Client Library
+// does not support. Requests are sent the same way as the Client Library sends them, using
+// the client's URL, token and Ably-Agent, and errors are returned as ably_control_go.ErrorInfo
+// so they are handled in the same way as errors from the Client Library.
+func (p *provider) request(method string, api_path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		in_data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(in_data)
+	}
+
+	req, err := http.NewRequest(method, p.client.Url+api_path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Ably-Agent", p.ably_agent)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		res_body, _ := io.ReadAll(res.Body)
+		var error_info ably_control_go.ErrorInfo
+		if err := json.Unmarshal(res_body, &error_info); err != nil {
+			error_info = ably_control_go.ErrorInfo{
+				Message:    string(res_body),
+				StatusCode: res.StatusCode,
+			}
+		}
+		error_info.APIPath = api_path
+		return error_info
+	}
+
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+	return nil
+}
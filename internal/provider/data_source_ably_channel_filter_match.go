@@ -0,0 +1,132 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type dataSourceChannelFilterMatch struct {
+	p *provider
+}
+
+// Get Channel Filter Match Data Source schema
+func (d dataSourceChannelFilterMatch) Schema(_ context.Context, _ tfsdk_datasource.SchemaRequest, resp *tfsdk_datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"channel_filter": schema.StringAttribute{
+				Optional:    true,
+				Description: "The channel filter of a rule source, such as the source.channel_filter attribute of a rule resource. Rules without a channel filter match all channels.",
+			},
+			"channels": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The channel names to test against the channel filter.",
+			},
+			"matching_channels": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The channels the channel filter matches, in the order they are given in channels.",
+			},
+			"non_matching_channels": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The channels the channel filter does not match, in the order they are given in channels.",
+			},
+		},
+		MarkdownDescription: "The ably_channel_filter_match data source tests which channels a rule channel filter matches, without calling the Ably API. The filter is a regular expression which matches a channel when it matches any part of the channel name, so use `^` and `$` to match whole names. Ably evaluates filters as JavaScript regular expressions, so filters are reported as errors when they use JavaScript features without an equivalent in Go, such as lookarounds and backreferences, or Go syntax which JavaScript reads differently, such as `(?i)` flags, `(?P<name>)` groups, `\\A`, `\\z` and `\\p{L}` escapes and `[[:alpha:]]` classes. Read more about channel filters in Ably documentation: https://ably.com/docs/general/integrations#channel-filter.",
+	}
+}
+
+func (d dataSourceChannelFilterMatch) Metadata(ctx context.Context, req tfsdk_datasource.MetadataRequest, resp *tfsdk_datasource.MetadataResponse) {
+	resp.TypeName = "ably_channel_filter_match"
+}
+
+// Read data source
+func (d dataSourceChannelFilterMatch) Read(ctx context.Context, req tfsdk_datasource.ReadRequest, resp *tfsdk_datasource.ReadResponse) {
+	var config AblyChannelFilterMatch
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := compileChannelFilter(config.ChannelFilter.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("channel_filter"), "Invalid Channel Filter", err.Error())
+		return
+	}
+
+	config.MatchingChannels = []string{}
+	config.NonMatchingChannels = []string{}
+	for _, channel := range config.Channels {
+		if filter.MatchString(channel) {
+			config.MatchingChannels = append(config.MatchingChannels, channel)
+		} else {
+			config.NonMatchingChannels = append(config.NonMatchingChannels, channel)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Compiles a rule channel filter. Like a JavaScript regular expression test, the filter matches a channel
+// when it matches any part of the channel name, and an empty filter matches all channels.
+func compileChannelFilter(channel_filter string) (*regexp.Regexp, error) {
+	if err := checkJsChannelFilter(channel_filter); err != nil {
+		return nil, fmt.Errorf("channel_filter must be a regular expression supported by both JavaScript and Go, got: %q, error: %s", channel_filter, err.Error())
+	}
+
+	filter, err := regexp.Compile(channel_filter)
+	if err != nil {
+		return nil, fmt.Errorf("channel_filter must be a regular expression supported by both JavaScript and Go, got: %q, error: %s", channel_filter, err.Error())
+	}
+
+	return filter, nil
+}
+
+// Checks a channel filter does not use Go regular expression syntax which JavaScript rejects or reads
+// differently, such as flags like (?i), (?P<name>) groups, \A, \z, \p{L} and \Q...\E escapes, and
+// [[:alpha:]] classes. Other differences are reported when the filter is compiled.
+func checkJsChannelFilter(channel_filter string) error {
+	in_class := false
+	for i := 0; i < len(channel_filter); i++ {
+		switch c := channel_filter[i]; {
+		case c == '\\' && i+1 < len(channel_filter):
+			i++
+			if strings.IndexByte("APpQEzC", channel_filter[i]) >= 0 {
+				return fmt.Errorf("the \\%c escape is not supported by JavaScript", channel_filter[i])
+			}
+		case in_class:
+			if c == ']' {
+				in_class = false
+			} else if c == '[' && strings.HasPrefix(channel_filter[i+1:], ":") {
+				return fmt.Errorf("[: character classes are not supported by JavaScript")
+			}
+		case c == '[':
+			in_class = true
+			// A ] at the start of a class is a literal in Go, but an empty class in JavaScript.
+			if strings.HasPrefix(channel_filter[i+1:], "]") || strings.HasPrefix(channel_filter[i+1:], "^]") {
+				return fmt.Errorf("classes starting with ] are not supported by JavaScript")
+			}
+		case c == '(' && strings.HasPrefix(channel_filter[i+1:], "?"):
+			group := channel_filter[i+2:]
+			named := strings.HasPrefix(group, "<") && !strings.HasPrefix(group, "<=") && !strings.HasPrefix(group, "<!")
+			if !strings.HasPrefix(group, ":") && !named {
+				return fmt.Errorf("only (?: and (?<name> groups are supported by both JavaScript and Go, got: %q", "(?"+group[:min(len(group), 2)])
+			}
+		}
+	}
+
+	return nil
+}
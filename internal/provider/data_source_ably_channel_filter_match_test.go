@@ -0,0 +1,89 @@
+package ably_control
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyChannelFilterMatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyChannelFilterMatchConfig("^my-channel.*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ably_channel_filter_match.filter0", "matching_channels.#", "2"),
+					resource.TestCheckResourceAttr("data.ably_channel_filter_match.filter0", "matching_channels.0", "my-channel"),
+					resource.TestCheckResourceAttr("data.ably_channel_filter_match.filter0", "matching_channels.1", "my-channel:1"),
+					resource.TestCheckResourceAttr("data.ably_channel_filter_match.filter0", "non_matching_channels.#", "1"),
+					resource.TestCheckResourceAttr("data.ably_channel_filter_match.filter0", "non_matching_channels.0", "other:my-channel"),
+				),
+			},
+			{
+				Config:      testAccAblyChannelFilterMatchConfig("^(?!private).*"),
+				ExpectError: regexp.MustCompile("Invalid Channel Filter"),
+			},
+		},
+	})
+}
+
+func TestCompileChannelFilter(t *testing.T) {
+	cases := []struct {
+		channel_filter string
+		channel        string
+		expected       bool
+	}{
+		{"", "any-channel", true},
+		{"chat", "room:chat:1", true},
+		{"^chat", "room:chat:1", false},
+		{"^room:[0-9]+$", "room:42", true},
+		{"^room:[0-9]+$", "room:42:presence", false},
+		{`^(?:chat|room):(?<id>\d+)$`, "chat:1", true},
+		{`^room:[[\]]`, "room:[", true},
+	}
+
+	for _, c := range cases {
+		filter, err := compileChannelFilter(c.channel_filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := filter.MatchString(c.channel); actual != c.expected {
+			t.Errorf("channel filter %q matching %q = %t, expected %t", c.channel_filter, c.channel, actual, c.expected)
+		}
+	}
+
+	if _, err := compileChannelFilter(`^(\w+):\1$`); err == nil {
+		t.Error("expected error for channel filter with a backreference")
+	}
+
+	for _, channel_filter := range []string{`(?i)^chat`, `^(?i:chat)`, `^(?P<id>\d+)`, `^chat\z`, `\Achat`, `^\pL+$`, `^\p{Greek}`, `\Q.\E`, `^[[:alpha:]]+$`, `^[]a]`, `(?=chat)`} {
+		if _, err := compileChannelFilter(channel_filter); err == nil {
+			t.Errorf("expected error for channel filter %q which JavaScript rejects or reads differently", channel_filter)
+		}
+	}
+}
+
+// Function with inline HCL to test channels against a channel filter
+func testAccAblyChannelFilterMatchConfig(channelFilter string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+data "ably_channel_filter_match" "filter0" {
+	channel_filter = %[1]q
+	channels       = ["my-channel", "other:my-channel", "my-channel:1"]
+}
+`, channelFilter)
+}
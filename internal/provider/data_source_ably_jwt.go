@@ -0,0 +1,87 @@
+package ably_control
+
+import (
+	"context"
+	"time"
+
+	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type dataSourceJwt struct {
+	p *provider
+}
+
+// Get JWT Data Source schema
+func (d dataSourceJwt) Schema(_ context.Context, _ tfsdk_datasource.SchemaRequest, resp *tfsdk_datasource.SchemaResponse) {
+	attributes := getTokenSchemaAttributes()
+	attributes["claims"] = schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Additional claims to include in the JWT. Can not include iat, exp or claims starting with x-ably-, which are set from the other attributes.",
+	}
+	attributes["token"] = schema.StringAttribute{
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The signed Ably JWT.",
+	}
+	attributes["issued_at"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "RFC3339 timestamp of when the JWT was issued.",
+	}
+	attributes["expires_at"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "RFC3339 timestamp of when the JWT expires.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: "The ably_jwt data source signs an Ably JWT with an API key, without calling the Ably API. A new JWT is signed every time the data source is read. Read more about Ably JWTs in Ably documentation: https://ably.com/docs/auth/token#jwt.",
+	}
+}
+
+func (d dataSourceJwt) Metadata(ctx context.Context, req tfsdk_datasource.MetadataRequest, resp *tfsdk_datasource.MetadataResponse) {
+	resp.TypeName = "ably_jwt"
+}
+
+// Read data source
+func (d dataSourceJwt) Read(ctx context.Context, req tfsdk_datasource.ReadRequest, resp *tfsdk_datasource.ReadResponse) {
+	var config AblyJwt
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateJwtClaims(config.Claims)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := getTokenParams(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issued_at := time.Now().UTC()
+	token, err := signAblyJwt(params, config.Claims, issued_at)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error signing JWT",
+			"Could not sign JWT, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	config.Token = types.StringValue(token)
+	config.IssuedAt = types.StringValue(issued_at.Format(time.RFC3339))
+	config.ExpiresAt = types.StringValue(issued_at.Add(params.Ttl).Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,72 @@
+package ably_control
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyJwt(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyJwtConfig(app_name, `["subscribe"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.ably_jwt.jwt0", "token", regexp.MustCompile(`^[\w-]+\.[\w-]+\.[\w-]+$`)),
+				),
+			},
+			{
+				Config:      testAccAblyJwtConfig(app_name, `["presence"]`),
+				ExpectError: regexp.MustCompile("Capability not granted by key"),
+			},
+		},
+	})
+}
+
+// Function with inline HCL to provision an ably_api_key resource and sign a token with it
+// Takes App name and the token operations on channel100 as function params.
+func testAccAblyJwtConfig(appName string, operations string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_api_key" "key0" {
+	app_id = ably_app.app0.id
+	name   = "token signer"
+	capabilities = {
+		"channel100" = ["publish", "subscribe"]
+	}
+}
+
+data "ably_jwt" "jwt0" {
+	key              = ably_api_key.key0.key
+	key_capabilities = ably_api_key.key0.capabilities
+	client_id        = "acc-test"
+	ttl              = "30m"
+	capabilities = {
+		"channel100" = %[2]s
+	}
+}
+`, appName, operations)
+}
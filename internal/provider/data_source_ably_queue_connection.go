@@ -0,0 +1,219 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type dataSourceQueueConnection struct {
+	p *provider
+}
+
+// Get Queue Connection Data Source schema
+func (d dataSourceQueueConnection) Schema(_ context.Context, _ tfsdk_datasource.SchemaRequest, resp *tfsdk_datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The application ID.",
+			},
+			"queue_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the queue.",
+			},
+			"key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The complete API key used to consume from the queue. The key must belong to the application and have the subscribe capability on the queue.",
+			},
+			"amqp_connection_url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "URL for connecting to the AMQP queue interface, including the API key credentials.",
+			},
+			"amqp_queue_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the Ably queue to consume from over AMQP.",
+			},
+			"stomp_connection_url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "URL for connecting to the STOMP queue interface, including the API key credentials.",
+			},
+			"stomp_host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The host to send in the STOMP CONNECT frame.",
+			},
+			"stomp_destination": schema.StringAttribute{
+				Computed:    true,
+				Description: "Destination to subscribe to over STOMP.",
+			},
+		},
+		MarkdownDescription: "The ably_queue_connection data source builds connection URLs for consuming from an Ably queue with an API key. Read more about consuming from Ably queues in Ably documentation: https://ably.com/docs/general/queues#consume.",
+	}
+}
+
+func (d dataSourceQueueConnection) Metadata(ctx context.Context, req tfsdk_datasource.MetadataRequest, resp *tfsdk_datasource.MetadataResponse) {
+	resp.TypeName = "ably_queue_connection"
+}
+
+// Read data source
+func (d dataSourceQueueConnection) Read(ctx context.Context, req tfsdk_datasource.ReadRequest, resp *tfsdk_datasource.ReadResponse) {
+	// Checks whether the provider and API Client are configured. If they are not, the provider responds with an error.
+	if !d.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply",
+		)
+		return
+	}
+
+	var config AblyQueueConnection
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_id := config.AppID.ValueString()
+	queue_id := config.QueueID.ValueString()
+	key := config.Key.ValueString()
+
+	key_name, key_secret, found := strings.Cut(key, ":")
+	if !found || !strings.HasPrefix(key_name, app_id+".") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Invalid API key",
+			fmt.Sprintf("The key must be a complete API key of application '%s' in the format <app_id>.<key_id>:<key_secret>.", app_id),
+		)
+		return
+	}
+
+	// NOTE: Control API & Client Lib do not currently support fetching single queue given queue id
+	queues, err := d.p.client.Queues(app_id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Data Source",
+			"Could not read queues, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var queue_found bool
+	for _, v := range queues {
+		if v.ID != queue_id {
+			continue
+		}
+		queue_found = true
+
+		config.AmqpQueueName = types.StringValue(v.Amqp.QueueName)
+		config.StompHost = types.StringValue(v.Stomp.Host)
+		config.StompDestination = types.StringValue(v.Stomp.Destination)
+
+		amqp_url, err := getQueueConnectionUrl(v.Amqp.Uri, key_name, key_secret)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid AMQP URI", fmt.Sprintf("Could not build AMQP connection URL from '%s': %s", v.Amqp.Uri, err.Error()))
+			return
+		}
+		config.AmqpConnectionUrl = types.StringValue(amqp_url)
+
+		stomp_url, err := getQueueConnectionUrl(v.Stomp.Uri, key_name, key_secret)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid STOMP URI", fmt.Sprintf("Could not build STOMP connection URL from '%s': %s", v.Stomp.Uri, err.Error()))
+			return
+		}
+		config.StompConnectionUrl = types.StringValue(stomp_url)
+		break
+	}
+
+	if !queue_found {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("queue_id"),
+			"Queue not found",
+			fmt.Sprintf("Could not find queue '%s' in application '%s'.", queue_id, app_id),
+		)
+		return
+	}
+
+	// Checks the key is allowed to consume from the queue
+	keys, err := d.p.client.Keys(app_id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Data Source",
+			"Could not read keys, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var key_found bool
+	for _, v := range keys {
+		if v.Key != key {
+			continue
+		}
+		key_found = true
+
+		if !canConsumeQueue(v.Capability, config.AmqpQueueName.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("key"),
+				"API key can not consume from queue",
+				fmt.Sprintf("Key '%s' needs the subscribe capability on '[queue]%s' to consume from the queue.", v.Name, config.AmqpQueueName.ValueString()),
+			)
+			return
+		}
+		break
+	}
+
+	if !key_found {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"API key not found",
+			fmt.Sprintf("Could not find the key in application '%s'. It may have been revoked or its secret changed.", app_id),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Adds the API key credentials to a queue interface URI. The key name and secret are
+// escaped as the secret may contain characters which are reserved in URLs.
+func getQueueConnectionUrl(uri string, key_name string, key_secret string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("missing scheme or host")
+	}
+
+	u.User = url.UserPassword(key_name, key_secret)
+	return u.String(), nil
+}
+
+// Checks whether a key capability allows subscribing to the queue with the given AMQP name,
+// including through wildcard resources such as [queue]appid:* or [*]*.
+func canConsumeQueue(capability map[string][]string, queue_name string) bool {
+	for pattern, ops := range capability {
+		if !capabilityResourceMatches(pattern, "[queue]"+queue_name) {
+			continue
+		}
+		for _, op := range ops {
+			if op == "subscribe" || op == "*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
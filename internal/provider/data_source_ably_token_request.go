@@ -0,0 +1,87 @@
+package ably_control
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type dataSourceTokenRequest struct {
+	p *provider
+}
+
+// Get Token Request Data Source schema
+func (d dataSourceTokenRequest) Schema(_ context.Context, _ tfsdk_datasource.SchemaRequest, resp *tfsdk_datasource.SchemaResponse) {
+	attributes := getTokenSchemaAttributes()
+	attributes["token_request"] = schema.StringAttribute{
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The signed TokenRequest as JSON, which a client exchanges for a token with the Ably REST API.",
+	}
+	attributes["timestamp"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: "Unix timestamp in milliseconds of when the TokenRequest was signed.",
+	}
+	attributes["nonce"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The random nonce of the TokenRequest.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: "The ably_token_request data source signs an Ably TokenRequest with an API key, without calling the Ably API. A new TokenRequest is signed every time the data source is read, and must be used within 60 minutes. Read more about Ably TokenRequests in Ably documentation: https://ably.com/docs/auth/token#token-request.",
+	}
+}
+
+func (d dataSourceTokenRequest) Metadata(ctx context.Context, req tfsdk_datasource.MetadataRequest, resp *tfsdk_datasource.MetadataResponse) {
+	resp.TypeName = "ably_token_request"
+}
+
+// Read data source
+func (d dataSourceTokenRequest) Read(ctx context.Context, req tfsdk_datasource.ReadRequest, resp *tfsdk_datasource.ReadResponse) {
+	var config AblyTokenRequest
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := getTokenParams(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nonce, err := tokenNonce()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error signing TokenRequest",
+			"Could not generate nonce, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	token_request := signAblyTokenRequest(params, time.Now(), nonce)
+	token_request_json, err := json.Marshal(token_request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error signing TokenRequest",
+			"Could not encode TokenRequest, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	config.TokenRequest = types.StringValue(string(token_request_json))
+	config.Timestamp = types.Int64Value(token_request.Timestamp)
+	config.Nonce = types.StringValue(token_request.Nonce)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
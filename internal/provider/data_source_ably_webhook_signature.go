@@ -0,0 +1,94 @@
+package ably_control
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type dataSourceWebhookSignature struct {
+	p *provider
+}
+
+// Get Webhook Signature Data Source schema
+func (d dataSourceWebhookSignature) Schema(_ context.Context, _ tfsdk_datasource.SchemaRequest, resp *tfsdk_datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The complete API key the rule signs payloads with, such as the key attribute of the ably_api_key resource referenced by signing_key_id.",
+			},
+			"payload": schema.StringAttribute{
+				Required:    true,
+				Description: "The raw body of the webhook request, exactly as sent by Ably.",
+			},
+			"verify_signature": schema.StringAttribute{
+				Optional:    true,
+				Description: "A signature to verify against the payload, such as the X-Ably-Signature header of a captured webhook request.",
+			},
+			"key_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The key name sent in the X-Ably-Key header, in the format <app_id>.<key_id>.",
+			},
+			"signature": schema.StringAttribute{
+				Computed:    true,
+				Description: "The signature Ably sends in the X-Ably-Signature header, the base64 encoded HMAC-SHA256 of the payload using the key secret.",
+			},
+			"valid": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether verify_signature matches the signature. Only set when verify_signature is set.",
+			},
+		},
+		MarkdownDescription: "The ably_webhook_signature data source computes the signature of a webhook payload signed with an API key, as Ably does for rules with a signing_key_id, without calling the Ably API. It can be used to generate fixtures for testing webhook receivers. Read more about webhook security in Ably documentation: https://ably.com/docs/general/webhooks#security.",
+	}
+}
+
+func (d dataSourceWebhookSignature) Metadata(ctx context.Context, req tfsdk_datasource.MetadataRequest, resp *tfsdk_datasource.MetadataResponse) {
+	resp.TypeName = "ably_webhook_signature"
+}
+
+// Read data source
+func (d dataSourceWebhookSignature) Read(ctx context.Context, req tfsdk_datasource.ReadRequest, resp *tfsdk_datasource.ReadResponse) {
+	var config AblyWebhookSignature
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key_name, key_secret, err := parseAblyKey(config.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Invalid API key", err.Error())
+		return
+	}
+
+	signature := signWebhookPayload(key_secret, config.Payload.ValueString())
+
+	config.KeyName = types.StringValue(key_name)
+	config.Signature = types.StringValue(signature)
+	config.Valid = types.BoolNull()
+	if !config.VerifySignature.IsNull() {
+		config.Valid = types.BoolValue(hmac.Equal([]byte(config.VerifySignature.ValueString()), []byte(signature)))
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Computes the webhook signature Ably sends in the X-Ably-Signature header.
+func signWebhookPayload(key_secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key_secret))
+	mac.Write([]byte(payload))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,60 @@
+package ably_control
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyWebhookSignature(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyWebhookSignatureConfig("ecRqxtsrMf8ypx2G4xYhIona8WPDvgeRynCkKBojWio="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ably_webhook_signature.sig0", "key_name", "appid.keyid"),
+					resource.TestCheckResourceAttr("data.ably_webhook_signature.sig0", "signature", "ecRqxtsrMf8ypx2G4xYhIona8WPDvgeRynCkKBojWio="),
+					resource.TestCheckResourceAttr("data.ably_webhook_signature.sig0", "valid", "true"),
+				),
+			},
+			{
+				Config: testAccAblyWebhookSignatureConfig("bm90IGEgc2lnbmF0dXJl"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ably_webhook_signature.sig0", "valid", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := signWebhookPayload("se/cr+et", `{"items":[]}`)
+	if signature != "ecRqxtsrMf8ypx2G4xYhIona8WPDvgeRynCkKBojWio=" {
+		t.Errorf("unexpected webhook signature: %s", signature)
+	}
+}
+
+// Function with inline HCL to compute and verify the signature of a webhook payload
+func testAccAblyWebhookSignatureConfig(verifySignature string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+data "ably_webhook_signature" "sig0" {
+	key              = "appid.keyid:se/cr+et"
+	payload          = jsonencode({ items = [] })
+	verify_signature = %[1]q
+}
+`, verifySignature)
+}
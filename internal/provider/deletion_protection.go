@@ -0,0 +1,66 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Gets the deletion_protection attribute schema with the given default value.
+func GetDeletionProtectionSchema(default_value bool) schema.Attribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf("Prevents the resource from being destroyed or replaced while set to true. "+
+			"It must be set to false in a prior apply before the resource can be destroyed. Defaults to %t, including for imported resources.", default_value),
+		PlanModifiers: []planmodifier.Bool{
+			DefaultAttribute(types.BoolValue(default_value)),
+		},
+	}
+}
+
+// Checks whether deletion protection is enabled in the prior state of a resource.
+func CheckDeletionProtection(ctx context.Context, state tfsdk.State, name string) diag.Diagnostics {
+	var deletion_protection types.Bool
+	diags := state.GetAttribute(ctx, path.Root("deletion_protection"), &deletion_protection)
+	if diags.HasError() {
+		return diags
+	}
+
+	if deletion_protection.ValueBool() {
+		diags.AddError(
+			"Resource is protected from deletion",
+			fmt.Sprintf("Cannot destroy %s while deletion_protection is enabled. Set deletion_protection to false and apply the change before destroying or replacing it.", name),
+		)
+	}
+
+	return diags
+}
+
+// Checks deletion protection when a resource is planned to be destroyed or replaced.
+func ModifyPlanDeletionProtection(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse, name string, replace_paths ...path.Path) {
+	// Nothing to protect when the resource is being created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() || IsPlannedReplacement(ctx, req, resp, replace_paths...) {
+		resp.Diagnostics.Append(CheckDeletionProtection(ctx, req.State, name)...)
+	}
+}
+
+// Sets deletion_protection to its default value on import, so that imported resources
+// are protected in the same way as new ones without waiting for the next apply.
+func ImportDeletionProtection(ctx context.Context, resp *tfsdk_resource.ImportStateResponse, default_value bool) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), types.BoolValue(default_value))...)
+}
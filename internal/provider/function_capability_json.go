@@ -0,0 +1,49 @@
+package ably_control
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type functionCapabilityJson struct{}
+
+func (f functionCapabilityJson) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "capability_json"
+}
+
+// Get Capability JSON Function definition
+func (f functionCapabilityJson) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes capabilities as the JSON string used in Ably tokens.",
+		MarkdownDescription: "Encodes a map of capabilities, such as the capabilities attribute of an ably_api_key resource, as the JSON string used in Ably tokens and token requests. The resources and operations are sorted, so equal capabilities are always encoded the same way.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name: "capabilities",
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The capabilities, keyed by resource with the set of operations granted on each resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run function
+func (f functionCapabilityJson) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var capabilities map[string][]string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &capabilities))
+	if resp.Error != nil {
+		return
+	}
+
+	capability, err := capabilityJSON(capabilities)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, capability))
+}
@@ -0,0 +1,63 @@
+package ably_control
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyFunctionCapabilityJson(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyFunctionCapabilityJsonConfig(`{
+		"chat:*"     = ["subscribe", "publish"]
+		"channel100" = ["history"]
+	}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("capability", `{"channel100":["history"],"chat:*":["publish","subscribe"]}`),
+				),
+			},
+		},
+	})
+}
+
+func TestFunctionCapabilityJson(t *testing.T) {
+	capabilities := types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+		"chat:*":   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("subscribe"), types.StringValue("publish")}),
+		"[queue]*": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("*")}),
+	})
+
+	result, err := testRunFunction(t, functionCapabilityJson{}, capabilities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"[queue]*":["*"],"chat:*":["publish","subscribe"]}`; result.(types.String).ValueString() != expected {
+		t.Errorf("capability_json = %s, expected %s", result, expected)
+	}
+}
+
+// Function with inline HCL to encode capabilities
+func testAccAblyFunctionCapabilityJsonConfig(capabilities string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+output "capability" {
+	value = provider::ably::capability_json(%[1]s)
+}
+`, capabilities)
+}
@@ -0,0 +1,52 @@
+package ably_control
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type functionChannelMatches struct{}
+
+func (f functionChannelMatches) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "channel_matches"
+}
+
+// Get Channel Matches Function definition
+func (f functionChannelMatches) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Tests whether a rule channel filter matches a channel.",
+		MarkdownDescription: "Tests whether a rule channel filter matches a channel, in the same way as the ably_channel_filter_match data source. The filter is a regular expression which matches a channel when it matches any part of the channel name, and an empty or null filter matches all channels.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "channel_filter",
+				AllowNullValue:      true,
+				MarkdownDescription: "The channel filter of a rule source, such as the source.channel_filter attribute of a rule resource.",
+			},
+			function.StringParameter{
+				Name:                "channel",
+				MarkdownDescription: "The channel name to test against the channel filter.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run function
+func (f functionChannelMatches) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var channel_filter types.String
+	var channel string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &channel_filter, &channel))
+	if resp.Error != nil {
+		return
+	}
+
+	filter, err := compileChannelFilter(channel_filter.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, filter.MatchString(channel)))
+}
@@ -0,0 +1,81 @@
+package ably_control
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyFunctionChannelMatches(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyFunctionChannelMatchesConfig("^my-channel.*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("matches", "true"),
+					resource.TestCheckOutput("other_matches", "false"),
+				),
+			},
+			{
+				Config:      testAccAblyFunctionChannelMatchesConfig("^(?!private).*"),
+				ExpectError: regexp.MustCompile("channel_filter must be a regular expression"),
+			},
+		},
+	})
+}
+
+func TestFunctionChannelMatches(t *testing.T) {
+	cases := []struct {
+		channel_filter types.String
+		channel        string
+		expected       bool
+	}{
+		{types.StringNull(), "any-channel", true},
+		{types.StringValue(""), "any-channel", true},
+		{types.StringValue("chat"), "room:chat:1", true},
+		{types.StringValue("^chat"), "room:chat:1", false},
+	}
+
+	for _, c := range cases {
+		result, err := testRunFunction(t, functionChannelMatches{}, c.channel_filter, types.StringValue(c.channel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.(types.Bool).ValueBool() != c.expected {
+			t.Errorf("channel_matches(%s, %q) = %s, expected %t", c.channel_filter, c.channel, result, c.expected)
+		}
+	}
+
+	if _, err := testRunFunction(t, functionChannelMatches{}, types.StringValue("(?i)chat"), types.StringValue("chat")); err == nil {
+		t.Error("expected error for channel filter with a Go flag")
+	}
+}
+
+// Function with inline HCL to test channels against a channel filter
+func testAccAblyFunctionChannelMatchesConfig(channelFilter string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+output "matches" {
+	value = provider::ably::channel_matches(%[1]q, "my-channel:1")
+}
+
+output "other_matches" {
+	value = provider::ably::channel_matches(%[1]q, "other:my-channel")
+}
+`, channelFilter)
+}
@@ -0,0 +1,66 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type functionNamespaceOf struct{}
+
+func (f functionNamespaceOf) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "namespace_of"
+}
+
+// Get Namespace Of Function definition
+func (f functionNamespaceOf) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Gets the namespace of a channel.",
+		MarkdownDescription: "Gets the namespace of a channel, which is the part of the channel name before the first `:`. A channel without a `:` is in the namespace of its own name, in the same way an ably_namespace with the id `chat` applies to both the `chat` and `chat:*` channels. Channel params, such as `[?rewind=1]`, are ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "channel",
+				MarkdownDescription: "The channel name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run function
+func (f functionNamespaceOf) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var channel string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &channel))
+	if resp.Error != nil {
+		return
+	}
+
+	namespace, err := channelNamespace(channel)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, namespace))
+}
+
+// Gets the namespace of a channel, the part of the name before the first colon, ignoring channel params.
+func channelNamespace(channel string) (string, error) {
+	name := channel
+	if strings.HasPrefix(name, "[") {
+		qualifier, rest, found := strings.Cut(name[1:], "]")
+		if !found || !strings.HasPrefix(qualifier, "?") {
+			return "", fmt.Errorf("channel must be a channel name, optionally with channel params such as [?rewind=1], got: %q", channel)
+		}
+		name = rest
+	}
+
+	namespace, _, _ := strings.Cut(name, ":")
+	if namespace == "" {
+		return "", fmt.Errorf("channel must be a channel name which does not start with a colon, got: %q", channel)
+	}
+
+	return namespace, nil
+}
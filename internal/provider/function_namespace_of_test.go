@@ -0,0 +1,75 @@
+package ably_control
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyFunctionNamespaceOf(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyFunctionNamespaceOfConfig("chat:room:1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("namespace", "chat"),
+				),
+			},
+			{
+				Config:      testAccAblyFunctionNamespaceOfConfig("[meta]log"),
+				ExpectError: regexp.MustCompile("channel must be a channel name"),
+			},
+		},
+	})
+}
+
+func TestFunctionNamespaceOf(t *testing.T) {
+	cases := map[string]string{
+		"chat":                   "chat",
+		"chat:room":              "chat",
+		"chat:room:1":            "chat",
+		"[?rewind=1]chat:room:1": "chat",
+		"[?rewind=1]chat":        "chat",
+	}
+
+	for channel, expected := range cases {
+		result, err := testRunFunction(t, functionNamespaceOf{}, types.StringValue(channel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.(types.String).ValueString() != expected {
+			t.Errorf("namespace_of(%q) = %s, expected %q", channel, result, expected)
+		}
+	}
+
+	for _, channel := range []string{"", ":room", "[meta]log", "[?rewind=1", "[?rewind=1]"} {
+		if _, err := testRunFunction(t, functionNamespaceOf{}, types.StringValue(channel)); err == nil {
+			t.Errorf("expected error for channel %q", channel)
+		}
+	}
+}
+
+// Function with inline HCL to get the namespace of a channel
+func testAccAblyFunctionNamespaceOfConfig(channel string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+output "namespace" {
+	value = provider::ably::namespace_of(%[1]q)
+}
+`, channel)
+}
@@ -0,0 +1,69 @@
+package ably_control
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type functionParseKey struct{}
+
+// Parts of an API key returned by the parse_key function.
+type AblyKeyParts struct {
+	AppID     string `tfsdk:"app_id"`
+	KeyID     string `tfsdk:"key_id"`
+	KeyName   string `tfsdk:"key_name"`
+	KeySecret string `tfsdk:"key_secret"`
+}
+
+func (f functionParseKey) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_key"
+}
+
+// Get Parse Key Function definition
+func (f functionParseKey) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Splits an Ably API key into its parts.",
+		MarkdownDescription: "Splits a complete Ably API key in the format `<app_id>.<key_id>:<key_secret>` into an object with the `app_id`, `key_id`, `key_name` and `key_secret` attributes. The `key_name` is the `<app_id>.<key_id>` part, as used as the keyName of token requests.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "The complete API key, such as the key attribute of an ably_api_key resource.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"app_id":     types.StringType,
+				"key_id":     types.StringType,
+				"key_name":   types.StringType,
+				"key_secret": types.StringType,
+			},
+		},
+	}
+}
+
+// Run function
+func (f functionParseKey) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &key))
+	if resp.Error != nil {
+		return
+	}
+
+	key_name, key_secret, err := parseAblyKey(key)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	app_id, key_id, _ := strings.Cut(key_name, ".")
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, AblyKeyParts{
+		AppID:     app_id,
+		KeyID:     key_id,
+		KeyName:   key_name,
+		KeySecret: key_secret,
+	}))
+}
@@ -0,0 +1,84 @@
+package ably_control
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyFunctionParseKey(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyFunctionParseKeyConfig("appid.keyid:secret"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("app_id", "appid"),
+					resource.TestCheckOutput("key_id", "keyid"),
+					resource.TestCheckOutput("key_name", "appid.keyid"),
+				),
+			},
+			{
+				Config:      testAccAblyFunctionParseKeyConfig("appid.keyid"),
+				ExpectError: regexp.MustCompile("key must be a complete API key"),
+			},
+		},
+	})
+}
+
+func TestFunctionParseKey(t *testing.T) {
+	result, err := testRunFunction(t, functionParseKey{}, types.StringValue("appid.keyid:se:cret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := result.(types.Object).Attributes()
+	if parts["app_id"].(types.String).ValueString() != "appid" ||
+		parts["key_id"].(types.String).ValueString() != "keyid" ||
+		parts["key_name"].(types.String).ValueString() != "appid.keyid" ||
+		parts["key_secret"].(types.String).ValueString() != "se:cret" {
+		t.Errorf("unexpected key parts: %s", result)
+	}
+
+	for _, key := range []string{"", "appid.keyid", "keyid:secret", "appid.keyid:"} {
+		if _, err := testRunFunction(t, functionParseKey{}, types.StringValue(key)); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+}
+
+// Function with inline HCL to parse an API key
+func testAccAblyFunctionParseKeyConfig(key string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+locals {
+	key_parts = provider::ably::parse_key(%[1]q)
+}
+
+output "app_id" {
+	value = local.key_parts.app_id
+}
+
+output "key_id" {
+	value = local.key_parts.key_id
+}
+
+output "key_name" {
+	value = local.key_parts.key_name
+}
+`, key)
+}
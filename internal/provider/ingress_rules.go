@@ -8,7 +8,9 @@ import (
 	ably_control_go "github.com/ably/ably-control-go"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -50,6 +52,8 @@ func GetPlanIngressRule(plan AblyIngressRule) ably_control_go.NewIngressRule {
 
 // Maps response body to resource schema attributes.
 // Using plan to fill in values that the api does not return.
+// The Control API only returns the configured status of an ingress rule, not the
+// runtime state or errors of its connector, so these cannot be exposed yet.
 func GetIngressRuleResponse(ably_ingress_rule *ably_control_go.IngressRule, plan *AblyIngressRule) AblyIngressRule {
 	var resp_target interface{}
 
@@ -78,45 +82,44 @@ func GetIngressRuleResponse(ably_ingress_rule *ably_control_go.IngressRule, plan
 	}
 
 	resp_rule := AblyIngressRule{
-		ID:     types.StringValue(ably_ingress_rule.ID),
-		AppID:  types.StringValue(ably_ingress_rule.AppID),
-		Status: types.StringValue(ably_ingress_rule.Status),
-		Target: resp_target,
+		ID:                 types.StringValue(ably_ingress_rule.ID),
+		AppID:              types.StringValue(ably_ingress_rule.AppID),
+		Status:             types.StringValue(ably_ingress_rule.Status),
+		Target:             resp_target,
+		DeletionProtection: plan.DeletionProtection,
 	}
 
 	return resp_rule
 }
 
-func GetIngressRuleSchema(target map[string]tfsdk.Attribute, markdown_description string) tfsdk.Schema {
-	return tfsdk.Schema{
+func GetIngressRuleSchema(target map[string]schema.Attribute, markdown_description string) schema.Schema {
+	return schema.Schema{
 		MarkdownDescription: markdown_description,
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Type:        types.StringType,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The rule ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"app_id": {
-				Type:        types.StringType,
+			"app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The Ably application ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"status": {
-				Type:        types.StringType,
+			"status": schema.StringAttribute{
 				Optional:    true,
 				Description: "The status of the rule. Rules can be enabled or disabled.",
 			},
-			"target": {
+			"target": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "object (rule_source)",
-				Attributes:  tfsdk.SingleNestedAttributes(target),
+				Attributes:  target,
 			},
+			"deletion_protection": GetDeletionProtectionSchema(false),
 		},
 	}
 }
@@ -265,6 +268,11 @@ func DeleteIngressRule[T any](r IngressRule, ctx context.Context, req tfsdk_reso
 		return
 	}
 
+	resp.Diagnostics.Append(CheckDeletionProtection(ctx, req.State, fmt.Sprintf("%s ingress rule", r.Name()))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state := s.IngressRule()
 
 	// Gets the Ably App ID and Ably Rule ID value for the resource
@@ -291,6 +299,11 @@ func DeleteIngressRule[T any](r IngressRule, ctx context.Context, req tfsdk_reso
 	resp.State.RemoveResource(ctx)
 }
 
+// Checks deletion protection when the ingress rule is planned to be destroyed or replaced
+func ModifyPlanIngressRule(r IngressRule, ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	ModifyPlanDeletionProtection(ctx, req, resp, fmt.Sprintf("%s ingress rule", r.Name()), path.Root("app_id"))
+}
+
 // // Import resource
 func ImportIngressRuleResource(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse, fields ...string) {
 	// Save the import identifier in the id attribute
@@ -315,4 +328,5 @@ func ImportIngressRuleResource(ctx context.Context, req tfsdk_resource.ImportSta
 	for i, v := range fields {
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(v), idParts[i])...)
 	}
+	ImportDeletionProtection(ctx, resp, false)
 }
@@ -7,21 +7,68 @@ import (
 
 // Ably App
 type AblyApp struct {
-	AccountID              types.String `tfsdk:"account_id"`
-	ID                     types.String `tfsdk:"id"`
-	Name                   types.String `tfsdk:"name"`
-	Status                 types.String `tfsdk:"status"`
-	TLSOnly                types.Bool   `tfsdk:"tls_only"`
-	FcmKey                 types.String `tfsdk:"fcm_key"`
-	ApnsCertificate        types.String `tfsdk:"apns_certificate"`
-	ApnsPrivateKey         types.String `tfsdk:"apns_private_key"`
-	ApnsUseSandboxEndpoint types.Bool   `tfsdk:"apns_use_sandbox_endpoint"`
+	AccountID                types.String `tfsdk:"account_id"`
+	ID                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	Status                   types.String `tfsdk:"status"`
+	TLSOnly                  types.Bool   `tfsdk:"tls_only"`
+	FcmKey                   types.String `tfsdk:"fcm_key"`
+	FcmServiceAccount        types.String `tfsdk:"fcm_service_account"`
+	FcmProjectId             types.String `tfsdk:"fcm_project_id"`
+	ApnsCertificate          types.String `tfsdk:"apns_certificate"`
+	ApnsPrivateKey           types.String `tfsdk:"apns_private_key"`
+	ApnsP12                  types.String `tfsdk:"apns_p12"`
+	ApnsP12Password          types.String `tfsdk:"apns_p12_password"`
+	ApnsUseSandboxEndpoint   types.Bool   `tfsdk:"apns_use_sandbox_endpoint"`
+	ApnsCertificateExpiresAt types.String `tfsdk:"apns_certificate_expires_at"`
+	ApnsCertificateSubject   types.String `tfsdk:"apns_certificate_subject"`
+	DeletionProtection       types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Ably App Push Credentials
+type AblyAppPushCredentials struct {
+	AppID                    types.String `tfsdk:"app_id"`
+	ID                       types.String `tfsdk:"id"`
+	FcmKey                   types.String `tfsdk:"fcm_key"`
+	FcmServiceAccount        types.String `tfsdk:"fcm_service_account"`
+	FcmProjectId             types.String `tfsdk:"fcm_project_id"`
+	ApnsCertificate          types.String `tfsdk:"apns_certificate"`
+	ApnsPrivateKey           types.String `tfsdk:"apns_private_key"`
+	ApnsP12                  types.String `tfsdk:"apns_p12"`
+	ApnsP12Password          types.String `tfsdk:"apns_p12_password"`
+	ApnsUseSandboxEndpoint   types.Bool   `tfsdk:"apns_use_sandbox_endpoint"`
+	ApnsCertificateExpiresAt types.String `tfsdk:"apns_certificate_expires_at"`
+	ApnsCertificateSubject   types.String `tfsdk:"apns_certificate_subject"`
+	RotationTrigger          types.String `tfsdk:"rotation_trigger"`
 }
 
 // Ably Namespace
 type AblyNamespace struct {
-	AppID            types.String `tfsdk:"app_id"`
-	ID               types.String `tfsdk:"id"`
+	AppID                   types.String `tfsdk:"app_id"`
+	ID                      types.String `tfsdk:"id"`
+	Authenticated           types.Bool   `tfsdk:"authenticated"`
+	Persisted               types.Bool   `tfsdk:"persisted"`
+	PersistLast             types.Bool   `tfsdk:"persist_last"`
+	PushEnabled             types.Bool   `tfsdk:"push_enabled"`
+	TlsOnly                 types.Bool   `tfsdk:"tls_only"`
+	ExposeTimeserial        types.Bool   `tfsdk:"expose_timeserial"`
+	BatchingEnabled         types.Bool   `tfsdk:"batching_enabled"`
+	BatchingPolicy          types.String `tfsdk:"batching_policy"`
+	BatchingInterval        types.Int64  `tfsdk:"batching_interval"`
+	ConflationEnabled       types.Bool   `tfsdk:"conflation_enabled"`
+	ConflationInterval      types.Int64  `tfsdk:"conflation_interval"`
+	ConflationKey           types.String `tfsdk:"conflation_key"`
+	PopulateChannelRegistry types.Bool   `tfsdk:"populate_channel_registry"`
+}
+
+// Ably App Namespaces
+type AblyAppNamespaces struct {
+	AppID      types.String                    `tfsdk:"app_id"`
+	ID         types.String                    `tfsdk:"id"`
+	Namespaces map[string]AblyAppNamespaceRule `tfsdk:"namespaces"`
+}
+
+type AblyAppNamespaceRule struct {
 	Authenticated    types.Bool   `tfsdk:"authenticated"`
 	Persisted        types.Bool   `tfsdk:"persisted"`
 	PersistLast      types.Bool   `tfsdk:"persist_last"`
@@ -35,15 +82,42 @@ type AblyNamespace struct {
 
 // Ably Key
 type AblyKey struct {
-	ID              types.String        `tfsdk:"id"`
-	AppID           types.String        `tfsdk:"app_id"`
-	Name            types.String        `tfsdk:"name"`
-	RevocableTokens types.Bool          `tfsdk:"revocable_tokens"`
-	Capability      map[string][]string `tfsdk:"capabilities"`
-	Status          types.Int64         `tfsdk:"status"`
-	Key             types.String        `tfsdk:"key"`
-	Created         types.Int64         `tfsdk:"created"`
-	Modified        types.Int64         `tfsdk:"modified"`
+	ID               types.String        `tfsdk:"id"`
+	AppID            types.String        `tfsdk:"app_id"`
+	Name             types.String        `tfsdk:"name"`
+	RevocableTokens  types.Bool          `tfsdk:"revocable_tokens"`
+	Capability       map[string][]string `tfsdk:"capabilities"`
+	CapabilityBlocks []AblyKeyCapability `tfsdk:"capability"`
+	Status           types.Int64         `tfsdk:"status"`
+	Revoked          types.Bool          `tfsdk:"revoked"`
+	Key              types.String        `tfsdk:"key"`
+	KeyName          types.String        `tfsdk:"key_name"`
+	KeySecret        types.String        `tfsdk:"key_secret"`
+	Created          types.Int64         `tfsdk:"created"`
+	Modified         types.Int64         `tfsdk:"modified"`
+}
+
+// Ably API Key capability block
+type AblyKeyCapability struct {
+	Resource   types.String `tfsdk:"resource"`
+	Operations []string     `tfsdk:"operations"`
+}
+
+// Ably API Key Rotation
+type AblyApiKeyRotation struct {
+	ID                     types.String        `tfsdk:"id"`
+	AppID                  types.String        `tfsdk:"app_id"`
+	Name                   types.String        `tfsdk:"name"`
+	Capability             map[string][]string `tfsdk:"capabilities"`
+	CapabilityBlocks       []AblyKeyCapability `tfsdk:"capability"`
+	RevocableTokens        types.Bool          `tfsdk:"revocable_tokens"`
+	Overlap                types.String        `tfsdk:"overlap"`
+	RotationTriggers       types.Map           `tfsdk:"rotation_triggers"`
+	CurrentKey             types.String        `tfsdk:"current_key"`
+	PreviousKeyID          types.String        `tfsdk:"previous_key_id"`
+	PreviousKey            types.String        `tfsdk:"previous_key"`
+	RotatedAt              types.String        `tfsdk:"rotated_at"`
+	PreviousKeyRevokeAfter types.String        `tfsdk:"previous_key_revoke_after"`
 }
 
 // Ably Queue
@@ -69,6 +143,63 @@ type AblyQueue struct {
 	StatsAcknowledgementRate types.Float64 `tfsdk:"stats_acknowledgement_rate"`
 	Deadletter               types.Bool    `tfsdk:"deadletter"`
 	DeadletterID             types.String  `tfsdk:"deadletter_id"`
+	DeletionProtection       types.Bool    `tfsdk:"deletion_protection"`
+	ForceDestroy             types.Bool    `tfsdk:"force_destroy"`
+}
+
+// Ably Queue Connection
+type AblyQueueConnection struct {
+	AppID              types.String `tfsdk:"app_id"`
+	QueueID            types.String `tfsdk:"queue_id"`
+	Key                types.String `tfsdk:"key"`
+	AmqpConnectionUrl  types.String `tfsdk:"amqp_connection_url"`
+	AmqpQueueName      types.String `tfsdk:"amqp_queue_name"`
+	StompConnectionUrl types.String `tfsdk:"stomp_connection_url"`
+	StompHost          types.String `tfsdk:"stomp_host"`
+	StompDestination   types.String `tfsdk:"stomp_destination"`
+}
+
+// Ably JWT
+type AblyJwt struct {
+	Key           types.String        `tfsdk:"key"`
+	ClientID      types.String        `tfsdk:"client_id"`
+	Capability    map[string][]string `tfsdk:"capabilities"`
+	KeyCapability map[string][]string `tfsdk:"key_capabilities"`
+	Ttl           types.String        `tfsdk:"ttl"`
+	Claims        map[string]string   `tfsdk:"claims"`
+	Token         types.String        `tfsdk:"token"`
+	IssuedAt      types.String        `tfsdk:"issued_at"`
+	ExpiresAt     types.String        `tfsdk:"expires_at"`
+}
+
+// Ably Token Request
+type AblyTokenRequest struct {
+	Key           types.String        `tfsdk:"key"`
+	ClientID      types.String        `tfsdk:"client_id"`
+	Capability    map[string][]string `tfsdk:"capabilities"`
+	KeyCapability map[string][]string `tfsdk:"key_capabilities"`
+	Ttl           types.String        `tfsdk:"ttl"`
+	TokenRequest  types.String        `tfsdk:"token_request"`
+	Timestamp     types.Int64         `tfsdk:"timestamp"`
+	Nonce         types.String        `tfsdk:"nonce"`
+}
+
+// Ably Webhook Signature
+type AblyWebhookSignature struct {
+	Key             types.String `tfsdk:"key"`
+	Payload         types.String `tfsdk:"payload"`
+	VerifySignature types.String `tfsdk:"verify_signature"`
+	KeyName         types.String `tfsdk:"key_name"`
+	Signature       types.String `tfsdk:"signature"`
+	Valid           types.Bool   `tfsdk:"valid"`
+}
+
+// Ably Channel Filter Match
+type AblyChannelFilterMatch struct {
+	ChannelFilter       types.String `tfsdk:"channel_filter"`
+	Channels            []string     `tfsdk:"channels"`
+	MatchingChannels    []string     `tfsdk:"matching_channels"`
+	NonMatchingChannels []string     `tfsdk:"non_matching_channels"`
 }
 
 func emptyStringToNull(v *types.String) {
@@ -80,18 +211,20 @@ func emptyStringToNull(v *types.String) {
 // Ably Ingress Rule
 func (r *AblyIngressRuleDecoder[_]) IngressRule() AblyIngressRule {
 	return AblyIngressRule{
-		ID:     r.ID,
-		AppID:  r.AppID,
-		Status: r.Status,
-		Target: r.Target,
+		ID:                 r.ID,
+		AppID:              r.AppID,
+		Status:             r.Status,
+		Target:             r.Target,
+		DeletionProtection: r.DeletionProtection,
 	}
 }
 
 type AblyIngressRuleDecoder[T any] struct {
-	ID     types.String `tfsdk:"id"`
-	AppID  types.String `tfsdk:"app_id"`
-	Status types.String `tfsdk:"status"`
-	Target T            `tfsdk:"target"`
+	ID                 types.String `tfsdk:"id"`
+	AppID              types.String `tfsdk:"app_id"`
+	Status             types.String `tfsdk:"status"`
+	Target             T            `tfsdk:"target"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type AblyIngressRule AblyIngressRuleDecoder[any]
@@ -178,27 +311,30 @@ type AblyRuleTargetLambda struct {
 }
 
 type AblyRuleTargetGoogleFunction struct {
-	Region       string                 `tfsdk:"region"`
-	ProjectID    string                 `tfsdk:"project_id"`
-	FunctionName string                 `tfsdk:"function_name"`
-	Headers      []AblyRuleHeaders      `tfsdk:"headers"`
-	SigningKeyId string                 `tfsdk:"signing_key_id"`
-	Enveloped    bool                   `tfsdk:"enveloped"`
-	Format       ably_control_go.Format `tfsdk:"format"`
+	Region           string                 `tfsdk:"region"`
+	ProjectID        string                 `tfsdk:"project_id"`
+	FunctionName     string                 `tfsdk:"function_name"`
+	Headers          []AblyRuleHeaders      `tfsdk:"headers"`
+	SensitiveHeaders []AblyRuleHeaders      `tfsdk:"sensitive_headers"`
+	SigningKeyId     string                 `tfsdk:"signing_key_id"`
+	Enveloped        bool                   `tfsdk:"enveloped"`
+	Format           ably_control_go.Format `tfsdk:"format"`
 }
 
 type AblyRuleTargetCloudflareWorker struct {
-	Url          string            `tfsdk:"url"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId string            `tfsdk:"signing_key_id"`
+	Url              string            `tfsdk:"url"`
+	Headers          []AblyRuleHeaders `tfsdk:"headers"`
+	SensitiveHeaders []AblyRuleHeaders `tfsdk:"sensitive_headers"`
+	SigningKeyId     string            `tfsdk:"signing_key_id"`
 }
 
 type AblyRuleTargetHTTP struct {
-	Url          string                 `tfsdk:"url"`
-	Headers      []AblyRuleHeaders      `tfsdk:"headers"`
-	SigningKeyId string                 `tfsdk:"signing_key_id"`
-	Format       ably_control_go.Format `tfsdk:"format"`
-	Enveloped    bool                   `tfsdk:"enveloped"`
+	Url              string                 `tfsdk:"url"`
+	Headers          []AblyRuleHeaders      `tfsdk:"headers"`
+	SensitiveHeaders []AblyRuleHeaders      `tfsdk:"sensitive_headers"`
+	SigningKeyId     string                 `tfsdk:"signing_key_id"`
+	Format           ably_control_go.Format `tfsdk:"format"`
+	Enveloped        bool                   `tfsdk:"enveloped"`
 }
 
 type AblyRuleTargetPulsar struct {
@@ -217,9 +353,10 @@ type PulsarAuthentication struct {
 }
 
 type AblyRuleTargetZapier struct {
-	Url          string            `tfsdk:"url"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId string            `tfsdk:"signing_key_id"`
+	Url              string            `tfsdk:"url"`
+	Headers          []AblyRuleHeaders `tfsdk:"headers"`
+	SensitiveHeaders []AblyRuleHeaders `tfsdk:"sensitive_headers"`
+	SigningKeyId     string            `tfsdk:"signing_key_id"`
 }
 
 type AblyRuleTargetIFTTT struct {
@@ -231,6 +368,7 @@ type AblyRuleTargetAzureFunction struct {
 	AzureAppID        string                 `tfsdk:"azure_app_id"`
 	AzureFunctionName string                 `tfsdk:"function_name"`
 	Headers           []AblyRuleHeaders      `tfsdk:"headers"`
+	SensitiveHeaders  []AblyRuleHeaders      `tfsdk:"sensitive_headers"`
 	SigningKeyID      string                 `tfsdk:"signing_key_id"`
 	Format            ably_control_go.Format `tfsdk:"format"`
 }
@@ -249,10 +387,11 @@ type AblyRuleTargetKafka struct {
 }
 
 type AblyRuleTargetAmqp struct {
-	QueueID   string                 `tfsdk:"queue_id"`
-	Headers   []AblyRuleHeaders      `tfsdk:"headers"`
-	Enveloped bool                   `tfsdk:"enveloped"`
-	Format    ably_control_go.Format `tfsdk:"format"`
+	QueueID          string                 `tfsdk:"queue_id"`
+	Headers          []AblyRuleHeaders      `tfsdk:"headers"`
+	SensitiveHeaders []AblyRuleHeaders      `tfsdk:"sensitive_headers"`
+	Enveloped        bool                   `tfsdk:"enveloped"`
+	Format           ably_control_go.Format `tfsdk:"format"`
 }
 
 type AblyRuleTargetAmqpExternal struct {
@@ -263,6 +402,7 @@ type AblyRuleTargetAmqpExternal struct {
 	PersistentMessages bool                   `tfsdk:"persistent_messages"`
 	MessageTtl         types.Int64            `tfsdk:"message_ttl"`
 	Headers            []AblyRuleHeaders      `tfsdk:"headers"`
+	SensitiveHeaders   []AblyRuleHeaders      `tfsdk:"sensitive_headers"`
 	Enveloped          bool                   `tfsdk:"enveloped"`
 	Format             ably_control_go.Format `tfsdk:"format"`
 }
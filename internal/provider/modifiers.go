@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type DefaultAttributePlanModifier struct {
@@ -15,16 +18,28 @@ func DefaultAttribute(value attr.Value) DefaultAttributePlanModifier {
 	return DefaultAttributePlanModifier{Default: value}
 }
 
-func (m DefaultAttributePlanModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
-	if resp.AttributePlan == nil || req.AttributeConfig == nil {
+func (m DefaultAttributePlanModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
 		return
 	}
 
-	if !req.AttributeConfig.IsNull() {
+	resp.PlanValue = m.Default.(types.Bool)
+}
+
+func (m DefaultAttributePlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.PlanValue = m.Default.(types.Int64)
+}
+
+func (m DefaultAttributePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
 		return
 	}
 
-	resp.AttributePlan = m.Default
+	resp.PlanValue = m.Default.(types.String)
 }
 
 func (m DefaultAttributePlanModifier) Description(ctx context.Context) string {
@@ -34,3 +49,27 @@ func (m DefaultAttributePlanModifier) Description(ctx context.Context) string {
 func (m DefaultAttributePlanModifier) MarkdownDescription(ctx context.Context) string {
 	return m.Description(ctx)
 }
+
+// Checks whether an update plan replaces the resource. The replacement is detected from the
+// paths which require replacement, both those already added to the response and the given
+// paths of attributes with RequiresReplace modifiers.
+func IsPlannedReplacement(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse, replace_paths ...path.Path) bool {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return false
+	}
+
+	for _, p := range append(replace_paths, resp.RequiresReplace...) {
+		var state_value, plan_value attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &state_value)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &plan_value)...)
+		if resp.Diagnostics.HasError() {
+			return false
+		}
+
+		if !state_value.Equal(plan_value) {
+			return true
+		}
+	}
+
+	return false
+}
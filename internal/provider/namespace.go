@@ -0,0 +1,41 @@
+package ably_control
+
+import (
+	ably_control_go "github.com/ably/ably-control-go"
+)
+
+// A namespace including the conflation and channel registry settings, which the
+// Client Library does not support. Requests for these namespaces are sent with
+// the shared Control API request helper.
+type namespaceChannelRules struct {
+	ably_control_go.Namespace
+	ConflationEnabled       bool   `json:"conflationEnabled"`
+	ConflationInterval      *int   `json:"conflationInterval,omitempty"`
+	ConflationKey           string `json:"conflationKey,omitempty"`
+	PopulateChannelRegistry bool   `json:"populateChannelRegistry"`
+}
+
+// Lists the namespaces of an app, including their conflation and channel registry settings.
+func (p *provider) namespaceChannelRules(app_id string) ([]namespaceChannelRules, error) {
+	var out []namespaceChannelRules
+	err := p.request("GET", "/apps/"+app_id+"/namespaces", nil, &out)
+	return out, err
+}
+
+// Creates a namespace, including its conflation and channel registry settings.
+func (p *provider) createNamespaceChannelRules(app_id string, namespace *namespaceChannelRules) (namespaceChannelRules, error) {
+	var out namespaceChannelRules
+	err := p.request("POST", "/apps/"+app_id+"/namespaces", namespace, &out)
+	return out, err
+}
+
+// Updates a namespace, including its conflation and channel registry settings. Like the
+// Client Library, the ID is only sent in the path.
+func (p *provider) updateNamespaceChannelRules(app_id string, namespace *namespaceChannelRules) (namespaceChannelRules, error) {
+	in := *namespace
+	in.ID = ""
+
+	var out namespaceChannelRules
+	err := p.request("PATCH", "/apps/"+app_id+"/namespaces/"+namespace.ID, &in, &out)
+	return out, err
+}
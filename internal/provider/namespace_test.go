@@ -0,0 +1,60 @@
+package ably_control
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNamespaceChannelRules(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /apps/app0/namespaces":
+			w.Write([]byte(`[{"id":"conflated","conflationEnabled":true,"conflationInterval":1000,"conflationKey":"#{message.name}","populateChannelRegistry":true}]`))
+		case "PATCH /apps/app0/namespaces/conflated":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			w.Write([]byte(`{"id":"conflated","conflationEnabled":true,"conflationInterval":1000,"conflationKey":"#{message.name}","populateChannelRegistry":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &provider{client: &ably_control_go.Client{Url: server.URL}}
+
+	namespaces, err := p.namespaceChannelRules("app0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(namespaces) != 1 || namespaces[0].ID != "conflated" || !namespaces[0].ConflationEnabled || *namespaces[0].ConflationInterval != 1000 || namespaces[0].ConflationKey != "#{message.name}" || !namespaces[0].PopulateChannelRegistry {
+		t.Fatalf("unexpected namespaces: %+v", namespaces)
+	}
+
+	namespace := namespaceChannelRules{
+		Namespace:               ably_control_go.Namespace{ID: "conflated", Persisted: true},
+		PopulateChannelRegistry: true,
+	}
+	SetNamespaceConflation(&namespace, types.BoolValue(true), types.Int64Value(1000), types.StringValue("#{message.name}"))
+
+	if _, err := p.updateNamespaceChannelRules("app0", &namespace); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["id"]; ok {
+		t.Errorf("expected the id to be omitted from the request body: %v", body)
+	}
+	if body["persisted"] != true || body["conflationEnabled"] != true || body["conflationInterval"] != float64(1000) || body["conflationKey"] != "#{message.name}" || body["populateChannelRegistry"] != true {
+		t.Errorf("unexpected request body: %v", body)
+	}
+
+	if _, err := p.namespaceChannelRules("missing"); !is_404(err) {
+		t.Errorf("expected a 404 error, got: %v", err)
+	}
+}
@@ -2,19 +2,25 @@ package ably_control
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	ably_control_go "github.com/ably/ably-control-go"
 	tfsdk_datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	tfsdk_provider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const CONTROL_API_DEFAULT_URL = "https://control.ably.net/v1"
 
+// Gets the Ably-Agent header which the Client Library sends, for requests sent without it.
+func ablyAgent(version string) string {
+	return fmt.Sprintf("ably-control-go/%s terraform-provider-ably/%s", ably_control_go.VERSION, version)
+}
+
 func New(version string) tfsdk_provider.Provider {
 	return &provider{
 		version: version,
@@ -24,24 +30,29 @@ func New(version string) tfsdk_provider.Provider {
 type provider struct {
 	configured bool
 	client     *ably_control_go.Client
+	token      string
+	ably_agent string
 	version    string
 }
 
-// GetSchema
-func (p *provider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes: map[string]tfsdk.Attribute{
-			"token": {
-				Type:      types.StringType,
+func (p *provider) Metadata(ctx context.Context, req tfsdk_provider.MetadataRequest, resp *tfsdk_provider.MetadataResponse) {
+	resp.TypeName = "ably"
+	resp.Version = p.version
+}
+
+// Schema
+func (p *provider) Schema(_ context.Context, _ tfsdk_provider.SchemaRequest, resp *tfsdk_provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
 				Sensitive: true,
 				Optional:  true,
 			},
-			"url": {
-				Type:     types.StringType,
+			"url": schema.StringAttribute{
 				Optional: true,
 			},
 		},
-	}, nil
+	}
 }
 
 // Provider schema struct
@@ -119,6 +130,8 @@ func (p *provider) Configure(ctx context.Context, req tfsdk_provider.ConfigureRe
 	c.AppendAblyAgent("terraform-provider-ably", p.version)
 
 	p.client = &c
+	p.token = token
+	p.ably_agent = ablyAgent(p.version)
 	p.configured = true
 }
 
@@ -126,8 +139,11 @@ func (p *provider) Configure(ctx context.Context, req tfsdk_provider.ConfigureRe
 func (p *provider) Resources(context.Context) []func() tfsdk_resource.Resource {
 	return []func() tfsdk_resource.Resource{
 		func() tfsdk_resource.Resource { return resourceApp{p} },
+		func() tfsdk_resource.Resource { return resourceAppPushCredentials{p} },
 		func() tfsdk_resource.Resource { return resourceNamespace{p} },
+		func() tfsdk_resource.Resource { return resourceAppNamespaces{p} },
 		func() tfsdk_resource.Resource { return resourceKey{p} },
+		func() tfsdk_resource.Resource { return resourceApiKeyRotation{p} },
 		func() tfsdk_resource.Resource { return resourceQueue{p} },
 		func() tfsdk_resource.Resource { return resourceRuleKinesis{p} },
 		func() tfsdk_resource.Resource { return resourceRuleSqs{p} },
@@ -150,6 +166,24 @@ func (p *provider) Resources(context.Context) []func() tfsdk_resource.Resource {
 
 // DataSources - Gets the data sources this provider provides
 func (p *provider) DataSources(context.Context) []func() tfsdk_datasource.DataSource {
-	return []func() tfsdk_datasource.DataSource{}
+	return []func() tfsdk_datasource.DataSource{
+		func() tfsdk_datasource.DataSource { return dataSourceQueueConnection{p} },
+		func() tfsdk_datasource.DataSource { return dataSourceJwt{p} },
+		func() tfsdk_datasource.DataSource { return dataSourceTokenRequest{p} },
+		func() tfsdk_datasource.DataSource { return dataSourceWebhookSignature{p} },
+		func() tfsdk_datasource.DataSource { return dataSourceChannelFilterMatch{p} },
+	}
+
+}
 
+var _ tfsdk_provider.ProviderWithFunctions = &provider{}
+
+// Functions - Gets the functions this provider provides
+func (p *provider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function { return functionParseKey{} },
+		func() function.Function { return functionCapabilityJson{} },
+		func() function.Function { return functionNamespaceOf{} },
+		func() function.Function { return functionChannelMatches{} },
+	}
 }
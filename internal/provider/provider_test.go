@@ -1,9 +1,13 @@
 package ably_control
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
 	"os"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -39,3 +43,20 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("ABLY_ACCOUNT_TOKEN must be set for acceptance tests")
 	}
 }
+
+// Runs a provider function with the given arguments, returning its result and error.
+func testRunFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+	result, err := definition.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
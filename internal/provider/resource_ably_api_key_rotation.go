@@ -0,0 +1,474 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type resourceApiKeyRotation struct {
+	p *provider
+}
+
+// Get Resource schema
+func (r resourceApiKeyRotation) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the current key.",
+			},
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Ably application ID which the keys are associated with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name for the API keys. This is a friendly name for your reference.",
+			},
+			"capabilities": GetKeyCapabilitiesSchema("The capabilities that the keys have. More information on capabilities can be found in the [Ably documentation](https://ably.com/docs/core-features/authentication#capabilities-explained)."),
+			"revocable_tokens": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Allow tokens issued by the keys to be revoked. More information on Token Revocation can be found in the [Ably documentation](https://ably.com/docs/auth/revocation)",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
+				},
+			},
+			"overlap": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "How long the previous key remains valid after a rotation, as a duration such as 30m or 24h. Once the overlap has elapsed the next apply revokes the previous key. Defaults to 24h.",
+				PlanModifiers: []planmodifier.String{
+					DefaultAttribute(types.StringValue("24h")),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values which rotate the key when changed. A new key is created, the current key becomes the previous key and any earlier previous key is revoked.",
+			},
+			"current_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The complete current API key including API secret.",
+			},
+			"previous_key_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the previous key. Null when there is no previous key.",
+			},
+			"previous_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The complete previous API key including API secret. Null when there is no previous key.",
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC3339 timestamp of when the current key was created.",
+			},
+			"previous_key_revoke_after": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC3339 timestamp after which the previous key is revoked by the next apply. Null when there is no previous key.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"capability": GetKeyCapabilityBlockSchema(),
+		},
+		MarkdownDescription: "The `ably_api_key_rotation` resource manages an Ably API key which is rotated without downtime. " +
+			"On rotation a new key is created first and the replaced key stays valid as the previous key until the `overlap` has elapsed.",
+	}
+}
+
+func (r resourceApiKeyRotation) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
+	resp.TypeName = "ably_api_key_rotation"
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceApiKeyRotation{}
+
+// Validates the capabilities and overlap duration before planning
+func (r resourceApiKeyRotation) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateKeyCapabilities(ctx, req.Config)...)
+
+	var overlap types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("overlap"), &overlap)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || overlap.IsNull() || overlap.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(overlap.ValueString()); err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("overlap"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("overlap must be a non-negative duration such as 30m or 24h, got: %q", overlap.ValueString()),
+		)
+	}
+}
+
+// Gets the time after which the previous key should be revoked.
+func getPreviousKeyRevokeAfter(rotated_at types.String, overlap types.String) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, rotated_at.ValueString())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	d, err := time.ParseDuration(overlap.ValueString())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Add(d), nil
+}
+
+// Sets previous_key_revoke_after from the rotation time and overlap, or null when there is no previous key.
+func (k *AblyApiKeyRotation) setPreviousKeyRevokeAfter() {
+	k.PreviousKeyRevokeAfter = types.StringNull()
+	if k.PreviousKeyID.IsNull() {
+		return
+	}
+
+	if revoke_after, err := getPreviousKeyRevokeAfter(k.RotatedAt, k.Overlap); err == nil {
+		k.PreviousKeyRevokeAfter = types.StringValue(revoke_after.UTC().Format(time.RFC3339))
+	}
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceApiKeyRotation{}
+
+// Plans the rotation of the key when the triggers change, and the revocation of the
+// previous key once the overlap has elapsed.
+func (r resourceApiKeyRotation) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AblyApiKeyRotation
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	var state AblyApiKeyRotation
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RotationTriggers.Equal(state.RotationTriggers) {
+		// The current key becomes the previous key, and a new current key is created on apply
+		plan.ID = types.StringUnknown()
+		plan.CurrentKey = types.StringUnknown()
+		plan.PreviousKeyID = state.ID
+		plan.PreviousKey = state.CurrentKey
+		plan.RotatedAt = types.StringUnknown()
+		plan.PreviousKeyRevokeAfter = types.StringUnknown()
+	} else {
+		plan.ID = state.ID
+		plan.CurrentKey = state.CurrentKey
+		plan.PreviousKeyID = state.PreviousKeyID
+		plan.PreviousKey = state.PreviousKey
+		plan.RotatedAt = state.RotatedAt
+
+		if plan.Overlap.IsUnknown() {
+			plan.PreviousKeyRevokeAfter = types.StringUnknown()
+		} else {
+			plan.setPreviousKeyRevokeAfter()
+		}
+
+		// Plans the revocation of the previous key once the overlap has elapsed
+		if !plan.PreviousKeyRevokeAfter.IsNull() && !plan.PreviousKeyRevokeAfter.IsUnknown() {
+			revoke_after, err := time.Parse(time.RFC3339, plan.PreviousKeyRevokeAfter.ValueString())
+			if err == nil && !time.Now().Before(revoke_after) {
+				plan.PreviousKeyID = types.StringNull()
+				plan.PreviousKey = types.StringNull()
+				plan.PreviousKeyRevokeAfter = types.StringNull()
+			}
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Revokes a key, ignoring keys which no longer exist.
+func (r resourceApiKeyRotation) revokeKey(app_id string, key_id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := r.p.client.RevokeKey(app_id, key_id)
+	if err != nil && !is_404(err) {
+		diags.AddError(
+			"Error revoking key",
+			fmt.Sprintf("Could not revoke key '%s', unexpected error: %s", key_id, err.Error()),
+		)
+	}
+
+	return diags
+}
+
+// Create a new resource
+func (r resourceApiKeyRotation) Create(ctx context.Context, req tfsdk_resource.CreateRequest, resp *tfsdk_resource.CreateResponse) {
+	// Checks whether the provider and API Client are configured. If they are not, the provider responds with an error.
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply",
+		)
+		return
+	}
+
+	// Gets plan values
+	var plan AblyApiKeyRotation
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	new_key := ably_control_go.NewKey{
+		Name:            plan.Name.ValueString(),
+		Capability:      GetKeyCapabilities(plan.Capability, plan.CapabilityBlocks),
+		RevocableTokens: plan.RevocableTokens.ValueBool(),
+	}
+
+	// Creates a new Ably Key by invoking the CreateKey function from the Client Library
+	ably_key, err := r.p.client.CreateKey(plan.AppID.ValueString(), &new_key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Resource",
+			"Could not create resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(ably_key.ID)
+	plan.CurrentKey = types.StringValue(ably_key.Key)
+	plan.Name = types.StringValue(ably_key.Name)
+	SetKeyCapabilities(ably_key.Capability, &plan.Capability, &plan.CapabilityBlocks)
+	plan.RevocableTokens = types.BoolValue(ably_key.RevocableTokens)
+	plan.PreviousKeyID = types.StringNull()
+	plan.PreviousKey = types.StringNull()
+	plan.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.setPreviousKeyRevokeAfter()
+
+	// Sets state for the new key rotation.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource
+func (r resourceApiKeyRotation) Read(ctx context.Context, req tfsdk_resource.ReadRequest, resp *tfsdk_resource.ReadResponse) {
+	// Gets the current state. If it is unable to, the provider responds with an error.
+	var state AblyApiKeyRotation
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_id := state.AppID.ValueString()
+
+	// Fetches all Ably Keys for the Ably App. The function invokes the Client Library Keys() method.
+	keys, err := r.p.client.Keys(app_id)
+	if err != nil {
+		if is_404(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading Resource",
+			"Could not read resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	current_found := false
+	previous_found := false
+	for _, v := range keys {
+		if v.AppID != app_id || v.Status != 0 {
+			continue
+		}
+
+		if v.ID == state.ID.ValueString() {
+			current_found = true
+			state.Name = types.StringValue(v.Name)
+			SetKeyCapabilities(v.Capability, &state.Capability, &state.CapabilityBlocks)
+			state.RevocableTokens = types.BoolValue(v.RevocableTokens)
+			state.CurrentKey = types.StringValue(v.Key)
+		} else if v.ID == state.PreviousKeyID.ValueString() {
+			previous_found = true
+			state.PreviousKey = types.StringValue(v.Key)
+		}
+	}
+
+	// The rotation is recreated when the current key has been revoked outside of Terraform
+	if !current_found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !previous_found {
+		state.PreviousKeyID = types.StringNull()
+		state.PreviousKey = types.StringNull()
+	}
+	state.setPreviousKeyRevokeAfter()
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r resourceApiKeyRotation) Update(ctx context.Context, req tfsdk_resource.UpdateRequest, resp *tfsdk_resource.UpdateResponse) {
+	// Get plan values
+	var plan AblyApiKeyRotation
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state AblyApiKeyRotation
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_id := plan.AppID.ValueString()
+
+	key_values := ably_control_go.NewKey{
+		Name:            plan.Name.ValueString(),
+		Capability:      GetKeyCapabilities(plan.Capability, plan.CapabilityBlocks),
+		RevocableTokens: plan.RevocableTokens.ValueBool(),
+	}
+
+	// Set when the rotation moves the earlier previous key out of state, so it is revoked once the new key is saved
+	rotated_out_key_id := types.StringNull()
+
+	if plan.ID.IsUnknown() {
+		// Rotates the key. The new key is created before the earlier previous key is revoked, so a
+		// failure to create it leaves the keys in state valid.
+		ably_key, err := r.p.client.CreateKey(app_id, &key_values)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Resource",
+				"Could not create rotated key, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		rotated_out_key_id = state.PreviousKeyID
+		plan.ID = types.StringValue(ably_key.ID)
+		plan.CurrentKey = types.StringValue(ably_key.Key)
+		plan.PreviousKeyID = state.ID
+		plan.PreviousKey = state.CurrentKey
+		plan.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	} else {
+		// Revokes the previous key once the overlap has elapsed
+		if !state.PreviousKeyID.IsNull() && plan.PreviousKeyID.IsNull() {
+			resp.Diagnostics.Append(r.revokeKey(app_id, state.PreviousKeyID.ValueString())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		if !plan.Name.Equal(state.Name) || !plan.RevocableTokens.Equal(state.RevocableTokens) || !capabilitiesEqual(key_values.Capability, GetKeyCapabilities(state.Capability, state.CapabilityBlocks)) {
+			ably_key, err := r.p.client.UpdateKey(app_id, state.ID.ValueString(), &key_values)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error updating Resource",
+					"Could not update resource, unexpected error: "+err.Error(),
+				)
+				return
+			}
+			plan.CurrentKey = types.StringValue(ably_key.Key)
+		}
+	}
+	plan.setPreviousKeyRevokeAfter()
+
+	// Sets state.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revokes the earlier previous key after the new key is saved, so at most two keys remain valid
+	if !rotated_out_key_id.IsNull() {
+		diags = r.revokeKey(app_id, rotated_out_key_id.ValueString())
+		if diags.HasError() {
+			resp.Diagnostics.AddError(
+				"Error revoking rotated key",
+				fmt.Sprintf("The key was rotated, but the earlier previous key '%s' could not be revoked and is no longer tracked in state. Revoke it manually.", rotated_out_key_id.ValueString()),
+			)
+		}
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+// Delete resource
+func (r resourceApiKeyRotation) Delete(ctx context.Context, req tfsdk_resource.DeleteRequest, resp *tfsdk_resource.DeleteResponse) {
+	// Get current state
+	var state AblyApiKeyRotation
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_id := state.AppID.ValueString()
+
+	// Revokes both the current and previous keys
+	resp.Diagnostics.Append(r.revokeKey(app_id, state.ID.ValueString())...)
+	if !state.PreviousKeyID.IsNull() {
+		resp.Diagnostics.Append(r.revokeKey(app_id, state.PreviousKeyID.ValueString())...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+// Compares capabilities, ignoring the order of operations.
+func capabilitiesEqual(a map[string][]string, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for resource, a_ops := range a {
+		b_ops, ok := b[resource]
+		if !ok || len(a_ops) != len(b_ops) {
+			return false
+		}
+
+		ops := map[string]bool{}
+		for _, op := range b_ops {
+			ops[op] = true
+		}
+		for _, op := range a_ops {
+			if !ops[op] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package ably_control
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyApiKeyRotation(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	key_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Create and Read testing of ably_api_key_rotation.rotation0
+			{
+				Config: testAccAblyApiKeyRotationConfig(app_name, key_name, "1h", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_api_key_rotation.rotation0", "name", key_name),
+					resource.TestCheckResourceAttr("ably_api_key_rotation.rotation0", "overlap", "1h"),
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "current_key"),
+					resource.TestCheckNoResourceAttr("ably_api_key_rotation.rotation0", "previous_key_id"),
+					resource.TestCheckNoResourceAttr("ably_api_key_rotation.rotation0", "previous_key"),
+				),
+			},
+			// Rotation keeps the replaced key as the previous key
+			{
+				Config: testAccAblyApiKeyRotationConfig(app_name, key_name, "1h", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "current_key"),
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "previous_key_id"),
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "previous_key"),
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "previous_key_revoke_after"),
+				),
+			},
+			// The previous key is revoked once the overlap has elapsed
+			{
+				Config: testAccAblyApiKeyRotationConfig(app_name, key_name, "0s", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ably_api_key_rotation.rotation0", "current_key"),
+					resource.TestCheckNoResourceAttr("ably_api_key_rotation.rotation0", "previous_key_id"),
+					resource.TestCheckNoResourceAttr("ably_api_key_rotation.rotation0", "previous_key"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Function with inline HCL to provision an ably_api_key_rotation resource
+// Takes App name, key name, overlap and rotation trigger as function params.
+func testAccAblyApiKeyRotationConfig(appName string, keyName string, overlap string, trigger string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_api_key_rotation" "rotation0" {
+	app_id = ably_app.app0.id
+	name   = %[2]q
+	capabilities = {
+		"channel1" = ["publish", "subscribe"]
+	}
+	overlap = %[3]q
+	rotation_triggers = {
+		version = %[4]q
+	}
+}
+`, appName, keyName, overlap, trigger)
+}
@@ -2,12 +2,17 @@ package ably_control
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	ably_control_go "github.com/ably/ably-control-go"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -16,76 +21,136 @@ type resourceApp struct {
 }
 
 // Get App Resource schema
-func (r resourceApp) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Type:        types.StringType,
+func (r resourceApp) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The application ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"account_id": {
-				Type:        types.StringType,
+			"account_id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The ID of your Ably account.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"name": {
-				Type:        types.StringType,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The application name.",
 			},
 			// TODO: Update this after Control API bug has been fixed.
-			"status": {
-				Type:        types.StringType,
+			"status": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "The application status. Disabled applications will not accept new connections and will return an error to all clients. When creating a new application, ensure that its status is set to enabled.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.String{
 					DefaultAttribute(types.StringValue("enabled")),
 				},
 			},
-			"tls_only": {
-				Type:        types.BoolType,
+			"tls_only": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Enforce TLS for all connections. This setting overrides any channel setting.",
 			},
-			"fcm_key": {
-				Type:        types.StringType,
+			"fcm_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "The Firebase Cloud Messaging key.",
+				Description: "The Firebase Cloud Messaging legacy server key. Cannot be used with `fcm_service_account` or `fcm_project_id`.",
 			},
-			"apns_certificate": {
-				Type:        types.StringType,
+			"fcm_service_account": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Firebase Cloud Messaging service account key, as JSON. Used with `fcm_project_id` to authenticate with the FCM HTTP v1 API. Cannot be used with `fcm_key`.",
+			},
+			"fcm_project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Firebase project ID. Used with `fcm_service_account` to authenticate with the FCM HTTP v1 API. Cannot be used with `fcm_key`.",
+			},
+			"apns_certificate": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
 				Description: "The Apple Push Notification service certificate.",
 			},
-			"apns_private_key": {
-				Type:        types.StringType,
+			"apns_private_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
 				Description: "The Apple Push Notification service private key.",
 			},
-			"apns_use_sandbox_endpoint": {
-				Type:        types.BoolType,
+			"apns_p12": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Apple Push Notification service certificate and private key as a base64 encoded PKCS#12 (.p12) bundle, for example using `filebase64()`. The bundle is converted to PEM by the provider, and may use legacy or AES encryption and include CA certificates. Cannot be used with `apns_certificate` or `apns_private_key`.",
+			},
+			"apns_p12_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the `apns_p12` bundle.",
+			},
+			"apns_use_sandbox_endpoint": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Use the Apple Push Notification service sandbox endpoint.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					DefaultAttribute(types.BoolValue(false)),
+				Description: "Use the Apple Push Notification service sandbox endpoint. When unset, the current setting of the app is kept, so it can be managed by the `ably_app_push_credentials` resource instead. New apps use the production endpoint by default, and removing the attribute from the configuration does not reset it to false. The endpoint is only checked against the APNs certificate when it is set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"apns_certificate_expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the Apple Push Notification service certificate expires, in RFC 3339 format.",
+			},
+			"apns_certificate_subject": schema.StringAttribute{
+				Computed:    true,
+				Description: "The subject of the Apple Push Notification service certificate.",
+			},
+			"deletion_protection": GetDeletionProtectionSchema(true),
 		},
 		MarkdownDescription: "The `ably_app` resource allows you to create and manage Ably Apps " +
-			"and configure Ably Push notifications. Read more about Ably Push Notifications in Ably documentation: https://ably.com/docs/general/push",
-	}, nil
+			"and configure Ably Push notifications. Push credentials can also be managed separately with the `ably_app_push_credentials` resource. " +
+			"Read more about Ably Push Notifications in Ably documentation: https://ably.com/docs/general/push",
+	}
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceApp{}
+
+// Validates the push notification attributes before planning
+func (r resourceApp) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	var config AblyApp
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(ValidateFcmCredentials(config.FcmKey, config.FcmServiceAccount, config.FcmProjectId)...)
+	resp.Diagnostics.Append(ValidateApnsCredentials(config.ApnsCertificate, config.ApnsPrivateKey, config.ApnsP12, config.ApnsP12Password, config.ApnsUseSandboxEndpoint)...)
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceApp{}
+
+// Checks deletion protection and plans the APNs certificate details from the planned certificate
+func (r resourceApp) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	ModifyPlanDeletionProtection(ctx, req, resp, "app")
+
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AblyApp
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apns_certificate, _, _ := GetApnsCredentials(plan.ApnsCertificate, plan.ApnsPrivateKey, plan.ApnsP12, plan.ApnsP12Password)
+	plan.ApnsCertificateExpiresAt, plan.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r resourceApp) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -111,6 +176,16 @@ func (r resourceApp) Create(ctx context.Context, req tfsdk_resource.CreateReques
 		return
 	}
 
+	// Decodes the APNs certificate and private key from the PKCS#12 bundle if one is set
+	apns_certificate, apns_private_key, err := GetApnsCredentials(plan.ApnsCertificate, plan.ApnsPrivateKey, plan.ApnsP12, plan.ApnsP12Password)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Resource",
+			"Could not decode apns_p12, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	// Generates an API request body from the plan values
 	app_values := ably_control_go.NewApp{
 		ID:                     plan.ID.ValueString(),
@@ -118,8 +193,10 @@ func (r resourceApp) Create(ctx context.Context, req tfsdk_resource.CreateReques
 		Status:                 plan.Status.ValueString(),
 		TLSOnly:                plan.TLSOnly.ValueBool(),
 		FcmKey:                 plan.FcmKey.ValueString(),
-		ApnsCertificate:        plan.ApnsCertificate.ValueString(),
-		ApnsPrivateKey:         plan.ApnsPrivateKey.ValueString(),
+		FcmServiceAccount:      plan.FcmServiceAccount.ValueString(),
+		FcmProjectId:           plan.FcmProjectId.ValueString(),
+		ApnsCertificate:        apns_certificate.ValueString(),
+		ApnsPrivateKey:         apns_private_key.ValueString(),
 		ApnsUseSandboxEndpoint: plan.ApnsUseSandboxEndpoint.ValueBool(),
 	}
 
@@ -141,13 +218,23 @@ func (r resourceApp) Create(ctx context.Context, req tfsdk_resource.CreateReques
 		Status:                 types.StringValue(ably_app.Status),
 		TLSOnly:                types.BoolValue(ably_app.TLSOnly),
 		FcmKey:                 plan.FcmKey,
+		FcmServiceAccount:      plan.FcmServiceAccount,
+		FcmProjectId:           plan.FcmProjectId,
 		ApnsCertificate:        plan.ApnsCertificate,
 		ApnsPrivateKey:         plan.ApnsPrivateKey,
+		ApnsP12:                plan.ApnsP12,
+		ApnsP12Password:        plan.ApnsP12Password,
 		ApnsUseSandboxEndpoint: types.BoolValue(ably_app.ApnsUseSandboxEndpoint),
+		DeletionProtection:     plan.DeletionProtection,
 	}
 	emptyStringToNull(&resp_apps.FcmKey)
+	emptyStringToNull(&resp_apps.FcmServiceAccount)
+	emptyStringToNull(&resp_apps.FcmProjectId)
 	emptyStringToNull(&resp_apps.ApnsCertificate)
 	emptyStringToNull(&resp_apps.ApnsPrivateKey)
+	emptyStringToNull(&resp_apps.ApnsP12)
+	emptyStringToNull(&resp_apps.ApnsP12Password)
+	resp_apps.ApnsCertificateExpiresAt, resp_apps.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
 
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, resp_apps)
@@ -193,13 +280,24 @@ func (r resourceApp) Read(ctx context.Context, req tfsdk_resource.ReadRequest, r
 				Status:                 types.StringValue(v.Status),
 				TLSOnly:                types.BoolValue(v.TLSOnly),
 				FcmKey:                 state.FcmKey,
+				FcmServiceAccount:      state.FcmServiceAccount,
+				FcmProjectId:           state.FcmProjectId,
 				ApnsCertificate:        state.ApnsCertificate,
 				ApnsPrivateKey:         state.ApnsPrivateKey,
+				ApnsP12:                state.ApnsP12,
+				ApnsP12Password:        state.ApnsP12Password,
 				ApnsUseSandboxEndpoint: types.BoolValue(v.ApnsUseSandboxEndpoint),
+				DeletionProtection:     state.DeletionProtection,
 			}
 			emptyStringToNull(&resp_apps.FcmKey)
+			emptyStringToNull(&resp_apps.FcmServiceAccount)
+			emptyStringToNull(&resp_apps.FcmProjectId)
 			emptyStringToNull(&resp_apps.ApnsCertificate)
 			emptyStringToNull(&resp_apps.ApnsPrivateKey)
+			emptyStringToNull(&resp_apps.ApnsP12)
+			emptyStringToNull(&resp_apps.ApnsP12Password)
+			apns_certificate, _, _ := GetApnsCredentials(state.ApnsCertificate, state.ApnsPrivateKey, state.ApnsP12, state.ApnsP12Password)
+			resp_apps.ApnsCertificateExpiresAt, resp_apps.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
 			found = true
 
 			// Sets state to app values.
@@ -241,19 +339,58 @@ func (r resourceApp) Update(ctx context.Context, req tfsdk_resource.UpdateReques
 		app_id = state.ID.ValueString()
 	}
 
+	// Decodes the APNs certificate and private key from the PKCS#12 bundle if one is set
+	apns_certificate, apns_private_key, err := GetApnsCredentials(plan.ApnsCertificate, plan.ApnsPrivateKey, plan.ApnsP12, plan.ApnsP12Password)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Resource",
+			"Could not decode apns_p12, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	// Instantiates struct of type ably_control_go.App and sets values to output of plan
 	app_values := ably_control_go.NewApp{
 		Name:                   plan.Name.ValueString(),
 		Status:                 plan.Status.ValueString(),
 		TLSOnly:                plan.TLSOnly.ValueBool(),
 		FcmKey:                 plan.FcmKey.ValueString(),
-		ApnsCertificate:        plan.ApnsCertificate.ValueString(),
-		ApnsPrivateKey:         plan.ApnsPrivateKey.ValueString(),
+		FcmServiceAccount:      plan.FcmServiceAccount.ValueString(),
+		FcmProjectId:           plan.FcmProjectId.ValueString(),
+		ApnsCertificate:        apns_certificate.ValueString(),
+		ApnsPrivateKey:         apns_private_key.ValueString(),
 		ApnsUseSandboxEndpoint: plan.ApnsUseSandboxEndpoint.ValueBool(),
 	}
 
-	// Updates an Ably App. The function invokes the Client Library UpdateApp method.
-	ably_app, err := r.p.client.UpdateApp(app_id, &app_values)
+	var config_apns_use_sandbox_endpoint types.Bool
+	diags = req.Config.GetAttribute(ctx, path.Root("apns_use_sandbox_endpoint"), &config_apns_use_sandbox_endpoint)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state_apns_certificate, state_apns_private_key, _ := GetApnsCredentials(state.ApnsCertificate, state.ApnsPrivateKey, state.ApnsP12, state.ApnsP12Password)
+
+	// Push credentials which are not set in the plan or state are left unchanged, as they may be
+	// managed by the ably_app_push_credentials resource.
+	var unmanaged_fields []string
+	for name, values := range map[string][]types.String{
+		"fcmKey":            {plan.FcmKey, state.FcmKey},
+		"fcmServiceAccount": {plan.FcmServiceAccount, state.FcmServiceAccount},
+		"fcmProjectId":      {plan.FcmProjectId, state.FcmProjectId},
+		"apnsCertificate":   {apns_certificate, state_apns_certificate},
+		"apnsPrivateKey":    {apns_private_key, state_apns_private_key},
+	} {
+		if values[0].IsNull() && values[1].IsNull() {
+			unmanaged_fields = append(unmanaged_fields, name)
+		}
+	}
+	if config_apns_use_sandbox_endpoint.IsNull() {
+		unmanaged_fields = append(unmanaged_fields, "apnsUseSandboxEndpoint")
+	}
+
+	// Updates an Ably App, leaving the unmanaged push credentials unchanged.
+	ably_app, err := r.p.updateAppOmitting(app_id, &app_values, unmanaged_fields)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Resource",
@@ -269,13 +406,23 @@ func (r resourceApp) Update(ctx context.Context, req tfsdk_resource.UpdateReques
 		Status:                 types.StringValue(ably_app.Status),
 		TLSOnly:                types.BoolValue(ably_app.TLSOnly),
 		FcmKey:                 plan.FcmKey,
+		FcmServiceAccount:      plan.FcmServiceAccount,
+		FcmProjectId:           plan.FcmProjectId,
 		ApnsCertificate:        plan.ApnsCertificate,
 		ApnsPrivateKey:         plan.ApnsPrivateKey,
+		ApnsP12:                plan.ApnsP12,
+		ApnsP12Password:        plan.ApnsP12Password,
 		ApnsUseSandboxEndpoint: types.BoolValue(ably_app.ApnsUseSandboxEndpoint),
+		DeletionProtection:     plan.DeletionProtection,
 	}
 	emptyStringToNull(&resp_apps.FcmKey)
+	emptyStringToNull(&resp_apps.FcmServiceAccount)
+	emptyStringToNull(&resp_apps.FcmProjectId)
 	emptyStringToNull(&resp_apps.ApnsCertificate)
 	emptyStringToNull(&resp_apps.ApnsPrivateKey)
+	emptyStringToNull(&resp_apps.ApnsP12)
+	emptyStringToNull(&resp_apps.ApnsP12Password)
+	resp_apps.ApnsCertificateExpiresAt, resp_apps.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
 
 	// Sets state to new app.
 	diags = resp.State.Set(ctx, resp_apps)
@@ -295,6 +442,11 @@ func (r resourceApp) Delete(ctx context.Context, req tfsdk_resource.DeleteReques
 		return
 	}
 
+	resp.Diagnostics.Append(CheckDeletionProtection(ctx, req.State, "app")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Gets the current state. If it is unable to, the provider responds with an error.
 	app_id := state.ID.ValueString()
 
@@ -323,4 +475,63 @@ func (r resourceApp) ImportState(ctx context.Context, req tfsdk_resource.ImportS
 	// Save the import identifier in the id attribute
 	// Recent PR in TF Plugin Framework for paths but Hashicorp examples not updated - https://github.com/hashicorp/terraform-plugin-framework/pull/390
 	tfsdk_resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ImportDeletionProtection(ctx, resp, true)
+}
+
+// Validates the Firebase Cloud Messaging credentials. The legacy server key and the
+// HTTP v1 service account credentials are mutually exclusive, and the service account
+// must be a JSON object accompanied by the Firebase project ID.
+func ValidateFcmCredentials(fcm_key types.String, service_account types.String, project_id types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !fcm_key.IsNull() && (!service_account.IsNull() || !project_id.IsNull()) {
+		diags.AddAttributeError(
+			path.Root("fcm_key"),
+			"Invalid Attribute Combination",
+			"fcm_key cannot be set together with fcm_service_account or fcm_project_id, use either the legacy server key or the HTTP v1 service account credentials",
+		)
+		return diags
+	}
+
+	if service_account.IsUnknown() || project_id.IsUnknown() {
+		return diags
+	}
+
+	if !service_account.IsNull() && project_id.IsNull() {
+		diags.AddAttributeError(
+			path.Root("fcm_project_id"),
+			"Missing Attribute",
+			"fcm_project_id must be set when fcm_service_account is set",
+		)
+	}
+	if service_account.IsNull() && !project_id.IsNull() {
+		diags.AddAttributeError(
+			path.Root("fcm_service_account"),
+			"Missing Attribute",
+			"fcm_service_account must be set when fcm_project_id is set",
+		)
+	}
+	if service_account.IsNull() {
+		return diags
+	}
+
+	var account map[string]interface{}
+	if err := json.Unmarshal([]byte(service_account.ValueString()), &account); err != nil {
+		diags.AddAttributeError(
+			path.Root("fcm_service_account"),
+			"Invalid Attribute Value",
+			"fcm_service_account must be a JSON object, such as the contents of a Firebase service account key file: "+err.Error(),
+		)
+		return diags
+	}
+
+	if account_project_id, ok := account["project_id"].(string); ok && !project_id.IsNull() && account_project_id != project_id.ValueString() {
+		diags.AddAttributeError(
+			path.Root("fcm_project_id"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("fcm_project_id %q does not match the project_id %q of fcm_service_account", project_id.ValueString(), account_project_id),
+		)
+	}
+
+	return diags
 }
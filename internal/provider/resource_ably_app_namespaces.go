@@ -0,0 +1,439 @@
+package ably_control
+
+import (
+	"context"
+	"sort"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type resourceAppNamespaces struct {
+	p *provider
+}
+
+// Get App Namespaces Resource schema
+func (r resourceAppNamespaces) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The application ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The application ID. This resource is managed per application.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespaces": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "The complete set of namespaces for the application, keyed by the namespace or channel name that the channel rule will apply to. Namespaces which exist in the application but are not listed here will be deleted.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"authenticated": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Require clients to be authenticated to use channels in this namespace.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"persisted": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, messages will be stored for 24 hours.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"persist_last": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, the last message on each channel will persist for 365 days.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"push_enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, publishing messages with a push payload in the extras field is permitted.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"tls_only": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, only clients that are connected using TLS will be permitted to subscribe.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"expose_timeserial": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, messages received on a channel will contain a unique timeserial that can be referenced by later messages for use with message interactions.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"batching_enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "If true, channels within this namespace will start batching inbound messages instead of sending them out immediately to subscribers as per the configured policy.",
+							PlanModifiers: []planmodifier.Bool{
+								DefaultAttribute(types.BoolValue(false)),
+							},
+						},
+						"batching_policy": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "When configured, sets the policy for message batching. The only supported policy is `simple`. Requires `batching_enabled` to be true.",
+							PlanModifiers: []planmodifier.String{
+								DefaultAttribute(types.StringNull()),
+							},
+						},
+						"batching_interval": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "When configured, sets the maximium batching interval in the channel, in milliseconds. Must be between 20 and 1000. Requires `batching_enabled` to be true.",
+							PlanModifiers: []planmodifier.Int64{
+								DefaultAttribute(types.Int64Null()),
+							},
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: "The `ably_app_namespaces` resource authoritatively manages every namespace for channel rules in an Ably app. " +
+			"Namespaces that are not declared in the resource, including ones created in the Ably dashboard, are deleted. " +
+			"Do not use it together with `ably_namespace` resources for the same app. Read more in the Ably documentation: https://ably.com/docs/general/channel-rules-namespaces.",
+	}
+}
+
+func (r resourceAppNamespaces) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
+	resp.TypeName = "ably_app_namespaces"
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceAppNamespaces{}
+
+// Validates the batching attributes of each namespace before planning
+func (r resourceAppNamespaces) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	var config types.Map
+	diags := req.Config.GetAttribute(ctx, path.Root("namespaces"), &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.IsNull() || config.IsUnknown() {
+		return
+	}
+
+	var namespaces map[string]AblyAppNamespaceRule
+	diags = config.ElementsAs(ctx, &namespaces, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for id, v := range namespaces {
+		p := path.Root("namespaces").AtMapKey(id)
+		resp.Diagnostics.Append(ValidateNamespaceBatching(p, v.BatchingEnabled, v.BatchingPolicy, v.BatchingInterval)...)
+	}
+}
+
+// Converts a namespace from terraform format to control sdk format
+func GetPlanAppNamespace(id string, plan AblyAppNamespaceRule) ably_control_go.Namespace {
+	namespace_values := ably_control_go.Namespace{
+		ID:               id,
+		Authenticated:    plan.Authenticated.ValueBool(),
+		Persisted:        plan.Persisted.ValueBool(),
+		PersistLast:      plan.PersistLast.ValueBool(),
+		PushEnabled:      plan.PushEnabled.ValueBool(),
+		TlsOnly:          plan.TlsOnly.ValueBool(),
+		ExposeTimeserial: plan.ExposeTimeserial.ValueBool(),
+	}
+
+	SetNamespaceBatching(&namespace_values, plan.BatchingEnabled, plan.BatchingPolicy, plan.BatchingInterval)
+
+	return namespace_values
+}
+
+// Maps a namespace response body to the nested resource schema attributes,
+// using the prior plan or state values of the namespace for the batching fields.
+func GetAppNamespaceResponse(ably_namespace *ably_control_go.Namespace, prior AblyAppNamespaceRule) AblyAppNamespaceRule {
+	batchingPolicy, batchingInterval := GetNamespaceBatchingResponse(ably_namespace, prior.BatchingPolicy, prior.BatchingInterval)
+
+	return AblyAppNamespaceRule{
+		Authenticated:    types.BoolValue(ably_namespace.Authenticated),
+		Persisted:        types.BoolValue(ably_namespace.Persisted),
+		PersistLast:      types.BoolValue(ably_namespace.PersistLast),
+		PushEnabled:      types.BoolValue(ably_namespace.PushEnabled),
+		TlsOnly:          types.BoolValue(ably_namespace.TlsOnly),
+		ExposeTimeserial: types.BoolValue(ably_namespace.ExposeTimeserial),
+		BatchingEnabled:  types.BoolValue(ably_namespace.BatchingEnabled),
+		BatchingPolicy:   batchingPolicy,
+		BatchingInterval: batchingInterval,
+	}
+}
+
+// Reconciles the namespaces of an app with the plan. Namespaces in the plan are
+// created or updated and any other namespace in the app is deleted. The prior
+// namespaces are taken from state and are nil on create. If an error occurs
+// part-way through, the returned namespaces reflect the changes applied so far.
+func (r resourceAppNamespaces) reconcile(plan AblyAppNamespaces, prior map[string]AblyAppNamespaceRule) (AblyAppNamespaces, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	app_id := plan.AppID.ValueString()
+
+	// Fetches all Ably Namespaces in the app. The function invokes the Client Library Namespaces() method.
+	existing, err := r.p.client.Namespaces(app_id)
+	if err != nil {
+		diags.AddError(
+			"Error reading Resource",
+			"Could not read namespaces, unexpected error: "+err.Error(),
+		)
+		return AblyAppNamespaces{
+			AppID:      types.StringValue(app_id),
+			ID:         types.StringValue(app_id),
+			Namespaces: prior,
+		}, diags
+	}
+
+	existing_ids := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		existing_ids[v.ID] = true
+	}
+
+	// Applies namespaces in a stable order so that errors are reproducible.
+	ids := make([]string, 0, len(plan.Namespaces))
+	for id := range plan.Namespaces {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resp_namespaces := AblyAppNamespaces{
+		AppID:      types.StringValue(app_id),
+		ID:         types.StringValue(app_id),
+		Namespaces: make(map[string]AblyAppNamespaceRule, len(ids)),
+	}
+
+	// Starts from the prior namespaces which still exist, so that namespaces
+	// which have not been applied yet are kept if an error occurs.
+	for id, v := range prior {
+		if existing_ids[id] {
+			resp_namespaces.Namespaces[id] = v
+		}
+	}
+
+	for _, id := range ids {
+		namespace_values := GetPlanAppNamespace(id, plan.Namespaces[id])
+
+		var ably_namespace ably_control_go.Namespace
+		if existing_ids[id] {
+			ably_namespace, err = r.p.client.UpdateNamespace(app_id, &namespace_values)
+		} else {
+			ably_namespace, err = r.p.client.CreateNamespace(app_id, &namespace_values)
+		}
+		if err != nil {
+			diags.AddError(
+				"Error updating Resource",
+				"Could not apply namespace '"+id+"', unexpected error: "+err.Error(),
+			)
+			return resp_namespaces, diags
+		}
+
+		resp_namespaces.Namespaces[id] = GetAppNamespaceResponse(&ably_namespace, plan.Namespaces[id])
+	}
+
+	// Deletes any namespaces which are not managed by this resource.
+	for _, v := range existing {
+		if _, ok := plan.Namespaces[v.ID]; ok {
+			continue
+		}
+
+		err = r.p.client.DeleteNamespace(app_id, v.ID)
+		if err != nil && !is_404(err) {
+			diags.AddError(
+				"Error deleting Resource",
+				"Could not delete unmanaged namespace '"+v.ID+"', unexpected error: "+err.Error(),
+			)
+			return resp_namespaces, diags
+		}
+
+		delete(resp_namespaces.Namespaces, v.ID)
+	}
+
+	return resp_namespaces, diags
+}
+
+// Create a new resource
+func (r resourceAppNamespaces) Create(ctx context.Context, req tfsdk_resource.CreateRequest, resp *tfsdk_resource.CreateResponse) {
+	// Checks whether the provider and API Client are configured. If they are not, the provider responds with an error.
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply",
+		)
+		return
+	}
+
+	// Gets plan values
+	var plan AblyAppNamespaces
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp_namespaces, reconcile_diags := r.reconcile(plan, nil)
+	resp.Diagnostics.Append(reconcile_diags...)
+	if reconcile_diags.HasError() && len(resp_namespaces.Namespaces) == 0 {
+		return
+	}
+
+	// Sets state for the new Ably App Namespaces. This includes the namespaces
+	// created before any error, so that they are not lost.
+	diags = resp.State.Set(ctx, resp_namespaces)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource
+func (r resourceAppNamespaces) Read(ctx context.Context, req tfsdk_resource.ReadRequest, resp *tfsdk_resource.ReadResponse) {
+	// Gets the current state. If it is unable to, the provider responds with an error.
+	var state AblyAppNamespaces
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Gets the Ably App ID value for the resource
+	app_id := state.AppID.ValueString()
+
+	// Fetches all Ably Namespaces in the app, including ones which are not in the
+	// configuration, so that they show up as drift.
+	namespaces, err := r.p.client.Namespaces(app_id)
+	if err != nil {
+		if is_404(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading Resource",
+			"Could not read resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp_namespaces := AblyAppNamespaces{
+		AppID:      types.StringValue(app_id),
+		ID:         types.StringValue(app_id),
+		Namespaces: make(map[string]AblyAppNamespaceRule, len(namespaces)),
+	}
+
+	for _, v := range namespaces {
+		// Namespaces which are not in state have no prior batching values
+		prior, ok := state.Namespaces[v.ID]
+		if !ok {
+			prior = AblyAppNamespaceRule{
+				BatchingPolicy:   types.StringUnknown(),
+				BatchingInterval: types.Int64Unknown(),
+			}
+		}
+		resp_namespaces.Namespaces[v.ID] = GetAppNamespaceResponse(&v, prior)
+	}
+
+	// Sets state to namespace values.
+	diags = resp.State.Set(ctx, &resp_namespaces)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r resourceAppNamespaces) Update(ctx context.Context, req tfsdk_resource.UpdateRequest, resp *tfsdk_resource.UpdateResponse) {
+	// Get plan values
+	var plan AblyAppNamespaces
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get current state
+	var state AblyAppNamespaces
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp_namespaces, reconcile_diags := r.reconcile(plan, state.Namespaces)
+
+	resp.Diagnostics.Append(reconcile_diags...)
+
+	// Sets state to new namespaces. This includes the changes applied before
+	// any error, so that state matches the app.
+	diags = resp.State.Set(ctx, resp_namespaces)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r resourceAppNamespaces) Delete(ctx context.Context, req tfsdk_resource.DeleteRequest, resp *tfsdk_resource.DeleteResponse) {
+	// Get current state
+	var state AblyAppNamespaces
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_id := state.AppID.ValueString()
+
+	for namespace_id := range state.Namespaces {
+		err := r.p.client.DeleteNamespace(app_id, namespace_id)
+		if err != nil {
+			if is_404(err) {
+				resp.Diagnostics.AddWarning(
+					"Resource does not exist",
+					"Namespace '"+namespace_id+"' does not exist, it may have already been deleted: "+err.Error(),
+				)
+			} else {
+				resp.Diagnostics.AddError(
+					"Error deleting Resource",
+					"Could not delete namespace '"+namespace_id+"', unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+// Import resource
+func (r resourceAppNamespaces) ImportState(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse) {
+	ImportResource(ctx, req, resp, "app_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
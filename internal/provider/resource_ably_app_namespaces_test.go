@@ -0,0 +1,148 @@
+package ably_control
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAblyAppNamespaces(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	namespace_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Create and Read testing of ably_app_namespaces.namespaces0
+			{
+				Config: testAccAblyAppNamespacesConfig(app_name, namespace_name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "name", app_name),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", "namespaces.%", "2"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s.authenticated", namespace_name), "true"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s.persisted", namespace_name), "true"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s.push_enabled", namespace_name), "false"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s2.tls_only", namespace_name), "true"),
+				),
+			},
+			// Update and Read testing of ably_app_namespaces.namespaces0
+			{
+				Config: testAccAblyAppNamespacesConfig(app_name, namespace_name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "name", app_name),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", "namespaces.%", "2"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s.authenticated", namespace_name), "false"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s.persisted", namespace_name), "false"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s2.tls_only", namespace_name), "true"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s2.batching_policy", namespace_name), "simple"),
+					resource.TestCheckResourceAttr("ably_app_namespaces.namespaces0", fmt.Sprintf("namespaces.%s2.batching_interval", namespace_name), "100"),
+				),
+			},
+			// Import testing of ably_app_namespaces.namespaces0, including the batching settings
+			{
+				ResourceName:      "ably_app_namespaces.namespaces0",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Function with inline HCL to provision an ably_app_namespaces resource
+// Takes App name, a namespace prefix and the flag value for the first namespace as function params.
+func testAccAblyAppNamespacesConfig(appName string, namespaceName string, enabled bool) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_app_namespaces" "namespaces0" {
+  app_id = ably_app.app0.id
+
+  namespaces = {
+    %[2]q = {
+      authenticated = %[3]t
+      persisted     = %[3]t
+    }
+    "%[2]s2" = {
+      tls_only          = true
+      batching_enabled  = true
+      batching_policy   = "simple"
+      batching_interval = 100
+    }
+  }
+}
+
+`, appName, namespaceName, enabled)
+}
+
+func TestAppNamespacesReconcilePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /apps/app0/namespaces":
+			w.Write([]byte(`[{"id":"a"},{"id":"c"}]`))
+		case "PATCH /apps/app0/namespaces/a":
+			w.Write([]byte(`{"id":"a","persisted":true}`))
+		case "POST /apps/app0/namespaces":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"internal error","code":50000,"statusCode":500}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	r := resourceAppNamespaces{p: &provider{client: &ably_control_go.Client{Url: server.URL}}}
+	plan := AblyAppNamespaces{
+		AppID: types.StringValue("app0"),
+		Namespaces: map[string]AblyAppNamespaceRule{
+			"a": {Persisted: types.BoolValue(true)},
+			"b": {},
+		},
+	}
+	prior := map[string]AblyAppNamespaceRule{
+		"a": {Persisted: types.BoolValue(false)},
+		"c": {},
+		"d": {},
+	}
+
+	resp_namespaces, diags := r.reconcile(plan, prior)
+	if !diags.HasError() {
+		t.Fatal("expected an error creating namespace b")
+	}
+
+	// Namespace a was updated, b was never created and c still exists as
+	// unmanaged namespaces are only deleted once all others are applied.
+	if len(resp_namespaces.Namespaces) != 2 {
+		t.Fatalf("unexpected namespaces: %v", resp_namespaces.Namespaces)
+	}
+	if !resp_namespaces.Namespaces["a"].Persisted.ValueBool() {
+		t.Errorf("expected namespace a to be updated: %v", resp_namespaces.Namespaces["a"])
+	}
+	if _, ok := resp_namespaces.Namespaces["c"]; !ok {
+		t.Errorf("expected namespace c to be kept: %v", resp_namespaces.Namespaces)
+	}
+}
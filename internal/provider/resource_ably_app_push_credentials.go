@@ -0,0 +1,421 @@
+package ably_control
+
+import (
+	"context"
+	"fmt"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type resourceAppPushCredentials struct {
+	p *provider
+}
+
+// Get App Push Credentials Resource schema
+func (r resourceAppPushCredentials) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the application to configure push credentials for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The application ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fcm_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Firebase Cloud Messaging legacy server key. Cannot be used with `fcm_service_account` or `fcm_project_id`.",
+			},
+			"fcm_service_account": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Firebase Cloud Messaging service account key, as JSON. Used with `fcm_project_id` to authenticate with the FCM HTTP v1 API. Cannot be used with `fcm_key`.",
+			},
+			"fcm_project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Firebase project ID. Used with `fcm_service_account` to authenticate with the FCM HTTP v1 API. Cannot be used with `fcm_key`.",
+			},
+			"apns_certificate": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Apple Push Notification service certificate.",
+			},
+			"apns_private_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Apple Push Notification service private key.",
+			},
+			"apns_p12": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Apple Push Notification service certificate and private key as a base64 encoded PKCS#12 (.p12) bundle, for example using `filebase64()`. The bundle is converted to PEM by the provider, and may use legacy or AES encryption and include CA certificates. Cannot be used with `apns_certificate` or `apns_private_key`.",
+			},
+			"apns_p12_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the `apns_p12` bundle.",
+			},
+			"apns_use_sandbox_endpoint": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Use the Apple Push Notification service sandbox endpoint.",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
+				},
+			},
+			"apns_certificate_expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the Apple Push Notification service certificate expires, in RFC 3339 format.",
+			},
+			"apns_certificate_subject": schema.StringAttribute{
+				Computed:    true,
+				Description: "The subject of the Apple Push Notification service certificate.",
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional:    true,
+				Description: "An arbitrary value which, when changed, causes the credentials to be written to the application again. The Control API does not return push credentials, so use this to re-apply credentials which may have been changed outside of Terraform.",
+			},
+		},
+		MarkdownDescription: "The `ably_app_push_credentials` resource manages the Ably Push notification credentials of an app independently of the `ably_app` resource, " +
+			"so that credentials can be rotated without managing the app itself. Leave the push notification attributes of the corresponding `ably_app` unset. " +
+			"Credentials are updated in place and are only cleared when the resource is destroyed or moved to another app, so the resource can safely be used with `create_before_destroy`. " +
+			"Read more about Ably Push Notifications in Ably documentation: https://ably.com/docs/general/push",
+	}
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceAppPushCredentials{}
+
+// Validates the push notification attributes before planning
+func (r resourceAppPushCredentials) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	var config AblyAppPushCredentials
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(ValidateFcmCredentials(config.FcmKey, config.FcmServiceAccount, config.FcmProjectId)...)
+	// The sandbox endpoint defaults to false for push credentials
+	if config.ApnsUseSandboxEndpoint.IsNull() {
+		config.ApnsUseSandboxEndpoint = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(ValidateApnsCredentials(config.ApnsCertificate, config.ApnsPrivateKey, config.ApnsP12, config.ApnsP12Password, config.ApnsUseSandboxEndpoint)...)
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceAppPushCredentials{}
+
+// Plans the APNs certificate details from the planned certificate
+func (r resourceAppPushCredentials) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AblyAppPushCredentials
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apns_certificate, _, _ := GetApnsCredentials(plan.ApnsCertificate, plan.ApnsPrivateKey, plan.ApnsP12, plan.ApnsP12Password)
+	plan.ApnsCertificateExpiresAt, plan.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r resourceAppPushCredentials) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
+	resp.TypeName = "ably_app_push_credentials"
+}
+
+// Fetches the application with the given ID. The Control API & Client Lib do not
+// currently support fetching a single app, so all apps in the account are listed.
+func (r resourceAppPushCredentials) app(app_id string) (*ably_control_go.App, error) {
+	apps, err := r.p.client.Apps()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range apps {
+		if v.ID == app_id {
+			return &v, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Writes the push credentials to the application while preserving its other settings.
+func (r resourceAppPushCredentials) write(plan AblyAppPushCredentials) (AblyAppPushCredentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	app_id := plan.AppID.ValueString()
+
+	// Decodes the APNs certificate and private key from the PKCS#12 bundle if one is set
+	apns_certificate, apns_private_key, err := GetApnsCredentials(plan.ApnsCertificate, plan.ApnsPrivateKey, plan.ApnsP12, plan.ApnsP12Password)
+	if err != nil {
+		diags.AddError(
+			"Error updating Resource",
+			"Could not decode apns_p12, unexpected error: "+err.Error(),
+		)
+		return plan, diags
+	}
+
+	ably_app, err := r.app(app_id)
+	if err != nil {
+		diags.AddError(
+			"Error reading Resource",
+			"Could not read application, unexpected error: "+err.Error(),
+		)
+		return plan, diags
+	}
+	if ably_app == nil {
+		diags.AddError(
+			"Error reading Resource",
+			fmt.Sprintf("Application '%s' does not exist", app_id),
+		)
+		return plan, diags
+	}
+
+	// Instantiates struct of type ably_control_go.NewApp using the current app settings
+	// and the push credentials from the plan
+	app_values := ably_control_go.NewApp{
+		Name:                   ably_app.Name,
+		Status:                 ably_app.Status,
+		TLSOnly:                ably_app.TLSOnly,
+		FcmKey:                 plan.FcmKey.ValueString(),
+		FcmServiceAccount:      plan.FcmServiceAccount.ValueString(),
+		FcmProjectId:           plan.FcmProjectId.ValueString(),
+		ApnsCertificate:        apns_certificate.ValueString(),
+		ApnsPrivateKey:         apns_private_key.ValueString(),
+		ApnsUseSandboxEndpoint: plan.ApnsUseSandboxEndpoint.ValueBool(),
+	}
+
+	// Updates an Ably App. The function invokes the Client Library UpdateApp method.
+	updated_app, err := r.p.client.UpdateApp(app_id, &app_values)
+	if err != nil {
+		diags.AddError(
+			"Error updating Resource",
+			"Could not update push credentials, unexpected error: "+err.Error(),
+		)
+		return plan, diags
+	}
+
+	resp_credentials := AblyAppPushCredentials{
+		AppID:                  types.StringValue(updated_app.ID),
+		ID:                     types.StringValue(updated_app.ID),
+		FcmKey:                 plan.FcmKey,
+		FcmServiceAccount:      plan.FcmServiceAccount,
+		FcmProjectId:           plan.FcmProjectId,
+		ApnsCertificate:        plan.ApnsCertificate,
+		ApnsPrivateKey:         plan.ApnsPrivateKey,
+		ApnsP12:                plan.ApnsP12,
+		ApnsP12Password:        plan.ApnsP12Password,
+		ApnsUseSandboxEndpoint: types.BoolValue(updated_app.ApnsUseSandboxEndpoint),
+		RotationTrigger:        plan.RotationTrigger,
+	}
+	emptyStringToNull(&resp_credentials.FcmKey)
+	emptyStringToNull(&resp_credentials.FcmServiceAccount)
+	emptyStringToNull(&resp_credentials.FcmProjectId)
+	emptyStringToNull(&resp_credentials.ApnsCertificate)
+	emptyStringToNull(&resp_credentials.ApnsPrivateKey)
+	emptyStringToNull(&resp_credentials.ApnsP12)
+	emptyStringToNull(&resp_credentials.ApnsP12Password)
+	resp_credentials.ApnsCertificateExpiresAt, resp_credentials.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
+
+	return resp_credentials, diags
+}
+
+// Create a new resource
+func (r resourceAppPushCredentials) Create(ctx context.Context, req tfsdk_resource.CreateRequest, resp *tfsdk_resource.CreateResponse) {
+	// Checks whether the provider and API Client are configured. If they are not, the provider responds with an error.
+	if !r.p.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply",
+		)
+		return
+	}
+
+	// Gets plan values
+	var plan AblyAppPushCredentials
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp_credentials, diags := r.write(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sets state for the new push credentials.
+	diags = resp.State.Set(ctx, resp_credentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource
+func (r resourceAppPushCredentials) Read(ctx context.Context, req tfsdk_resource.ReadRequest, resp *tfsdk_resource.ReadResponse) {
+	// Gets the current state. If it is unable to, the provider responds with an error.
+	var state AblyAppPushCredentials
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ably_app, err := r.app(state.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Resource",
+			"Could not read resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if ably_app == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The Control API does not return push credentials, so they are kept from state.
+	resp_credentials := AblyAppPushCredentials{
+		AppID:                  types.StringValue(ably_app.ID),
+		ID:                     types.StringValue(ably_app.ID),
+		FcmKey:                 state.FcmKey,
+		FcmServiceAccount:      state.FcmServiceAccount,
+		FcmProjectId:           state.FcmProjectId,
+		ApnsCertificate:        state.ApnsCertificate,
+		ApnsPrivateKey:         state.ApnsPrivateKey,
+		ApnsP12:                state.ApnsP12,
+		ApnsP12Password:        state.ApnsP12Password,
+		ApnsUseSandboxEndpoint: types.BoolValue(ably_app.ApnsUseSandboxEndpoint),
+		RotationTrigger:        state.RotationTrigger,
+	}
+	emptyStringToNull(&resp_credentials.FcmKey)
+	emptyStringToNull(&resp_credentials.FcmServiceAccount)
+	emptyStringToNull(&resp_credentials.FcmProjectId)
+	emptyStringToNull(&resp_credentials.ApnsCertificate)
+	emptyStringToNull(&resp_credentials.ApnsPrivateKey)
+	emptyStringToNull(&resp_credentials.ApnsP12)
+	emptyStringToNull(&resp_credentials.ApnsP12Password)
+	apns_certificate, _, _ := GetApnsCredentials(state.ApnsCertificate, state.ApnsPrivateKey, state.ApnsP12, state.ApnsP12Password)
+	resp_credentials.ApnsCertificateExpiresAt, resp_credentials.ApnsCertificateSubject = GetApnsCertificateDetails(apns_certificate)
+
+	// Sets state to push credential values.
+	diags = resp.State.Set(ctx, &resp_credentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r resourceAppPushCredentials) Update(ctx context.Context, req tfsdk_resource.UpdateRequest, resp *tfsdk_resource.UpdateResponse) {
+	// Get plan values
+	var plan AblyAppPushCredentials
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp_credentials, diags := r.write(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sets state to new push credentials.
+	diags = resp.State.Set(ctx, resp_credentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r resourceAppPushCredentials) Delete(ctx context.Context, req tfsdk_resource.DeleteRequest, resp *tfsdk_resource.DeleteResponse) {
+	// Get current state
+	var state AblyAppPushCredentials
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ably_app, err := r.app(state.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Resource",
+			"Could not delete resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if ably_app == nil {
+		resp.Diagnostics.AddWarning(
+			"Resource does not exist",
+			"Application does not exist, it may have already been deleted",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Clears the push credentials while preserving the other app settings
+	app_values := ably_control_go.NewApp{
+		Name:    ably_app.Name,
+		Status:  ably_app.Status,
+		TLSOnly: ably_app.TLSOnly,
+	}
+
+	_, err = r.p.client.UpdateApp(ably_app.ID, &app_values)
+	if err != nil {
+		if is_404(err) {
+			resp.Diagnostics.AddWarning(
+				"Resource does not exist",
+				"Resource does not exist, it may have already been deleted: "+err.Error(),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error deleting Resource",
+				"Could not delete resource, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+// Import resource
+func (r resourceAppPushCredentials) ImportState(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse) {
+	ImportResource(ctx, req, resp, "app_id")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
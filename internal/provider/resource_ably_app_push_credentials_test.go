@@ -0,0 +1,211 @@
+package ably_control
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Test Create and Update of Ably app push credentials with:
+// Step 1: Create w/ params (fcm_key=a, rotation_trigger=1)
+// Step 2: Update w/ params (fcm_key=b, rotation_trigger=2)
+func TestAccAblyAppPushCredentials(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Create and Read testing of ably_app_push_credentials.push0
+			{
+				Config: testAccAblyAppPushCredentialsConfig(app_name, "a", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "name", app_name),
+					resource.TestCheckResourceAttrPair("ably_app_push_credentials.push0", "app_id", "ably_app.app0", "id"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "fcm_key", "a"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "apns_use_sandbox_endpoint", "true"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "rotation_trigger", "1"),
+				),
+			},
+			// Update and Read testing of ably_app_push_credentials.push0
+			{
+				Config: testAccAblyAppPushCredentialsConfig(app_name, "b", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "name", app_name),
+					resource.TestCheckResourceAttr("ably_app.app0", "tls_only", "true"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "fcm_key", "b"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "rotation_trigger", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Test the push credentials are kept when the app is updated with:
+// Step 1: Create w/ params (name=app_name, fcm_key=a, apns_use_sandbox_endpoint=true)
+// Step 2: Update the ably_app w/ params (name=acc-test-app_name)
+func TestAccAblyAppPushCredentialsAppUpdate(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	update_app_name := "acc-test-" + app_name
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyAppPushCredentialsConfig(app_name, "a", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "apns_use_sandbox_endpoint", "true"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "apns_use_sandbox_endpoint", "true"),
+				),
+			},
+			// Renaming the app must not clear the credentials written by ably_app_push_credentials.push0,
+			// and the following plan must be empty.
+			{
+				Config: testAccAblyAppPushCredentialsConfig(update_app_name, "a", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "name", update_app_name),
+					resource.TestCheckResourceAttr("ably_app.app0", "apns_use_sandbox_endpoint", "true"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "fcm_key", "a"),
+					testAccCheckAblyAppPushCredentials("ably_app.app0", "a"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Checks the app push credentials in the Control API have not been cleared.
+func testAccCheckAblyAppPushCredentials(resource_name string, fcm_key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource_name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resource_name)
+		}
+
+		url := os.Getenv("ABLY_URL")
+		if url == "" {
+			url = CONTROL_API_DEFAULT_URL
+		}
+		client, _, err := ably_control_go.NewClientWithURL(os.Getenv("ABLY_ACCOUNT_TOKEN"), url)
+		if err != nil {
+			return err
+		}
+
+		apps, err := client.Apps()
+		if err != nil {
+			return err
+		}
+
+		for _, app := range apps {
+			if app.ID != rs.Primary.ID {
+				continue
+			}
+			if !app.ApnsUseSandboxEndpoint {
+				return fmt.Errorf("apnsUseSandboxEndpoint of app %s was cleared", app.ID)
+			}
+			// The FCM key is only checked when the Control API returns it
+			if app.FcmKey != "" && app.FcmKey != fcm_key {
+				return fmt.Errorf("fcmKey of app %s is %q, expected %q", app.ID, app.FcmKey, fcm_key)
+			}
+			return nil
+		}
+
+		return fmt.Errorf("app not found: %s", rs.Primary.ID)
+	}
+}
+
+// Test Create of Ably app push credentials from a PKCS#12 bundle with:
+// Step 1: Create w/ params (apns_p12=testApnsP12, apns_p12_password=wrong) which should fail validation
+// Step 2: Create w/ params (apns_p12=testApnsP12, apns_p12_password=secret)
+func TestAccAblyAppPushCredentialsP12(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Validation of the apns_p12_password
+			{
+				Config:      testAccAblyAppPushCredentialsP12Config(app_name, testApnsP12, "wrong"),
+				ExpectError: regexp.MustCompile("apns_p12_password is not the password of apns_p12"),
+			},
+			// Create and Read testing of ably_app_push_credentials.push0
+			{
+				Config: testAccAblyAppPushCredentialsP12Config(app_name, testApnsP12, "secret"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ably_app_push_credentials.push0", "app_id", "ably_app.app0", "id"),
+					resource.TestCheckResourceAttr("ably_app_push_credentials.push0", "apns_certificate_subject", "CN=Apple Push Services: io.ably.tf-provider-test,O=Ably Real-time Ltd"),
+					resource.TestCheckResourceAttrSet("ably_app_push_credentials.push0", "apns_certificate_expires_at"),
+					resource.TestCheckNoResourceAttr("ably_app_push_credentials.push0", "apns_certificate"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Function with inline HCL to provision an ably_app_push_credentials resource
+// Takes App name, FCM key and rotation trigger as function params.
+func testAccAblyAppPushCredentialsConfig(appName string, fcmKey string, rotationTrigger string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_app_push_credentials" "push0" {
+	app_id                    = ably_app.app0.id
+	fcm_key                   = %[2]q
+	apns_use_sandbox_endpoint = true
+	rotation_trigger          = %[3]q
+}
+`, appName, fcmKey, rotationTrigger)
+}
+
+// Function with inline HCL to provision an ably_app_push_credentials resource from a PKCS#12 bundle
+// Takes App name, the base64 encoded bundle and its password as function params.
+func testAccAblyAppPushCredentialsP12Config(appName string, p12 string, password string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_app_push_credentials" "push0" {
+	app_id            = ably_app.app0.id
+	apns_p12          = %[2]q
+	apns_p12_password = %[3]q
+}
+`, appName, p12, password)
+}
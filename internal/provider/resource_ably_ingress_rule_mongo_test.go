@@ -91,9 +91,10 @@ terraform {
 provider "ably" {}
 
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_ingress_rule_mongodb" "rule0" {
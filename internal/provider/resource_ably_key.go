@@ -2,11 +2,16 @@ package ably_control
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	ably_control_go "github.com/ably/ably-control-go"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -15,80 +20,117 @@ type resourceKey struct {
 }
 
 // Get Resource schema
-func (r resourceKey) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Type:        types.StringType,
+func (r resourceKey) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The key ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"app_id": {
-				Type:        types.StringType,
+			"app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The Ably application ID which this key is associated with.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"name": {
-				Type:        types.StringType,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name for your API key. This is a friendly name for your reference.",
 			},
-			"capabilities": {
-				Type: types.MapType{
-					ElemType: types.SetType{
-						ElemType: types.StringType,
-					},
-				},
-				Required:    true,
-				Description: "The capabilities that this key has. More information on capabilities can be found in the [Ably documentation](https://ably.com/docs/core-features/authentication#capabilities-explained)",
-			},
-			"revocable_tokens": {
-				Type:        types.BoolType,
+			"capabilities": GetKeyCapabilitiesSchema("The capabilities that this key has. More information on capabilities can be found in the [Ably documentation](https://ably.com/docs/core-features/authentication#capabilities-explained)."),
+			"revocable_tokens": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "Allow tokens issued by this key to be revoked. More information on Token Revocation can be found in the [Ably documentation](https://ably.com/docs/auth/revocation)",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"status": {
-				Type:        types.Int64Type,
+			"status": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The status of the key. 0 is enabled, 1 is revoked.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Int64{
 					DefaultAttribute(types.Int64Value(0)),
 				},
 			},
-			"created": {
-				Type:        types.Int64Type,
+			"revoked": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the key has been revoked. A revoked key is kept in the state and replaced by the next apply.",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
+				},
+			},
+			"created": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Enforce TLS for all connections. This setting overrides any channel setting.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 				},
 			},
-			"key": {
-				Type:        types.StringType,
+			"key": schema.StringAttribute{
 				Computed:    true,
+				Sensitive:   true,
 				Description: "The complete API key including API secret.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the API key, in the format <app_id>.<key_id>.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"modified": {
-				Type:        types.Int64Type,
+			"key_secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret of the API key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Unix timestamp representing the date and time of the last modification of the key.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"capability": GetKeyCapabilityBlockSchema(),
+		},
 		MarkdownDescription: "The `ably_key` resource allows you to create and manage Ably API keys.",
-	}, nil
+	}
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceKey{}
+
+// Validates the capabilities before planning
+func (r resourceKey) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateKeyCapabilities(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceKey{}
+
+// Requires replacement of keys which have been revoked outside of Terraform
+func (r resourceKey) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var revoked types.Bool
+	diags := req.State.GetAttribute(ctx, path.Root("revoked"), &revoked)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if revoked.ValueBool() {
+		resp.RequiresReplace.Append(path.Root("revoked"))
+	}
 }
 
 func (r resourceKey) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -116,7 +158,7 @@ func (r resourceKey) Create(ctx context.Context, req tfsdk_resource.CreateReques
 
 	new_key := ably_control_go.NewKey{
 		Name:            plan.Name.ValueString(),
-		Capability:      plan.Capability,
+		Capability:      GetKeyCapabilities(plan.Capability, plan.CapabilityBlocks),
 		RevocableTokens: plan.RevocableTokens.ValueBool(),
 	}
 
@@ -132,16 +174,20 @@ func (r resourceKey) Create(ctx context.Context, req tfsdk_resource.CreateReques
 
 	// Maps response body to resource schema attributes.
 	resp_key := AblyKey{
-		ID:              types.StringValue(ably_key.ID),
-		AppID:           types.StringValue(ably_key.AppID),
-		Name:            types.StringValue(ably_key.Name),
-		Key:             types.StringValue(ably_key.Key),
-		RevocableTokens: types.BoolValue(ably_key.RevocableTokens),
-		Capability:      ably_key.Capability,
-		Status:          types.Int64Value(int64(ably_key.Status)),
-		Created:         types.Int64Value(int64(ably_key.Created)),
-		Modified:        types.Int64Value(int64(ably_key.Modified)),
+		ID:               types.StringValue(ably_key.ID),
+		AppID:            types.StringValue(ably_key.AppID),
+		Name:             types.StringValue(ably_key.Name),
+		Key:              types.StringValue(ably_key.Key),
+		KeyName:          getKeyName(ably_key.Key),
+		KeySecret:        getKeySecret(ably_key.Key),
+		RevocableTokens:  types.BoolValue(ably_key.RevocableTokens),
+		CapabilityBlocks: plan.CapabilityBlocks,
+		Status:           types.Int64Value(int64(ably_key.Status)),
+		Revoked:          types.BoolValue(ably_key.Status == 1),
+		Created:          types.Int64Value(int64(ably_key.Created)),
+		Modified:         types.Int64Value(int64(ably_key.Modified)),
 	}
+	SetKeyCapabilities(ably_key.Capability, &resp_key.Capability, &resp_key.CapabilityBlocks)
 
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, resp_key)
@@ -183,19 +229,31 @@ func (r resourceKey) Read(ctx context.Context, req tfsdk_resource.ReadRequest, r
 	}
 
 	// Loops through apps and if account id and key id match, sets state.
+	// Revoked keys are kept in the state so that replacing them is shown in the plan.
 	for _, v := range keys {
-		if v.AppID == app_id && v.ID == key_id && v.Status == 0 {
+		if v.AppID == app_id && v.ID == key_id {
+			if v.Status == 1 {
+				resp.Diagnostics.AddWarning(
+					"API key revoked",
+					fmt.Sprintf("Key '%s' (%s) has been revoked outside of Terraform. The next apply will replace it with a new key with a different secret.", v.Name, v.ID),
+				)
+			}
+
 			resp_key := AblyKey{
-				ID:              types.StringValue(v.ID),
-				AppID:           types.StringValue(v.AppID),
-				Name:            types.StringValue(v.Name),
-				RevocableTokens: types.BoolValue(v.RevocableTokens),
-				Capability:      v.Capability,
-				Status:          types.Int64Value(int64(v.Status)),
-				Key:             types.StringValue(v.Key),
-				Created:         types.Int64Value(int64(v.Created)),
-				Modified:        types.Int64Value(int64(v.Modified)),
+				ID:               types.StringValue(v.ID),
+				AppID:            types.StringValue(v.AppID),
+				Name:             types.StringValue(v.Name),
+				RevocableTokens:  types.BoolValue(v.RevocableTokens),
+				CapabilityBlocks: state.CapabilityBlocks,
+				Status:           types.Int64Value(int64(v.Status)),
+				Revoked:          types.BoolValue(v.Status == 1),
+				Key:              types.StringValue(v.Key),
+				KeyName:          getKeyName(v.Key),
+				KeySecret:        getKeySecret(v.Key),
+				Created:          types.Int64Value(int64(v.Created)),
+				Modified:         types.Int64Value(int64(v.Modified)),
 			}
+			SetKeyCapabilities(v.Capability, &resp_key.Capability, &resp_key.CapabilityBlocks)
 			// Sets state to app values.
 			diags = resp.State.Set(ctx, &resp_key)
 			found = true
@@ -238,7 +296,7 @@ func (r resourceKey) Update(ctx context.Context, req tfsdk_resource.UpdateReques
 	// Instantiates struct of type ably_control_go.NewKey and sets values to output of plan
 	key_values := ably_control_go.NewKey{
 		Name:            plan.Name.ValueString(),
-		Capability:      plan.Capability,
+		Capability:      GetKeyCapabilities(plan.Capability, plan.CapabilityBlocks),
 		RevocableTokens: plan.RevocableTokens.ValueBool(),
 	}
 
@@ -253,16 +311,20 @@ func (r resourceKey) Update(ctx context.Context, req tfsdk_resource.UpdateReques
 	}
 
 	resp_key := AblyKey{
-		ID:              types.StringValue(ably_key.ID),
-		AppID:           types.StringValue(ably_key.AppID),
-		Name:            types.StringValue(ably_key.Name),
-		RevocableTokens: types.BoolValue(ably_key.RevocableTokens),
-		Capability:      ably_key.Capability,
-		Status:          types.Int64Value(int64(ably_key.Status)),
-		Key:             types.StringValue(ably_key.Key),
-		Created:         types.Int64Value(int64(ably_key.Created)),
-		Modified:        types.Int64Value(int64(ably_key.Modified)),
+		ID:               types.StringValue(ably_key.ID),
+		AppID:            types.StringValue(ably_key.AppID),
+		Name:             types.StringValue(ably_key.Name),
+		RevocableTokens:  types.BoolValue(ably_key.RevocableTokens),
+		CapabilityBlocks: plan.CapabilityBlocks,
+		Status:           types.Int64Value(int64(ably_key.Status)),
+		Revoked:          types.BoolValue(ably_key.Status == 1),
+		Key:              types.StringValue(ably_key.Key),
+		KeyName:          getKeyName(ably_key.Key),
+		KeySecret:        getKeySecret(ably_key.Key),
+		Created:          types.Int64Value(int64(ably_key.Created)),
+		Modified:         types.Int64Value(int64(ably_key.Modified)),
 	}
+	SetKeyCapabilities(ably_key.Capability, &resp_key.Capability, &resp_key.CapabilityBlocks)
 
 	// Sets state.
 	diags = resp.State.Set(ctx, resp_key)
@@ -286,6 +348,12 @@ func (r resourceKey) Delete(ctx context.Context, req tfsdk_resource.DeleteReques
 	app_id := state.AppID.ValueString()
 	key_id := state.ID.ValueString()
 
+	// Keys which are already revoked only need to be removed from the state
+	if state.Revoked.ValueBool() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	err := r.p.client.RevokeKey(app_id, key_id)
 	if err != nil {
 		if is_404(err) {
@@ -306,6 +374,18 @@ func (r resourceKey) Delete(ctx context.Context, req tfsdk_resource.DeleteReques
 	resp.State.RemoveResource(ctx)
 }
 
+// Gets the name of an API key, the part before the secret.
+func getKeyName(key string) types.String {
+	key_name, _, _ := strings.Cut(key, ":")
+	return types.StringValue(key_name)
+}
+
+// Gets the secret of an API key.
+func getKeySecret(key string) types.String {
+	_, key_secret, _ := strings.Cut(key, ":")
+	return types.StringValue(key_secret)
+}
+
 // // Import resource
 func (r resourceKey) ImportState(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse) {
 	ImportResource(ctx, req, resp, "app_id", "id")
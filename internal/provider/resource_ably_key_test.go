@@ -2,8 +2,11 @@ package ably_control
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
+	ably_control_go "github.com/ably/ably-control-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -29,6 +32,8 @@ func TestAccAblyKey(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_api_key.key0", "revocable_tokens", "true"),
 					resource.TestCheckResourceAttr("ably_api_key.key0", "capabilities.channel100.0", "publish"),
 					resource.TestCheckResourceAttr("ably_api_key.key0", "capabilities.channel100.1", "subscribe"),
+					resource.TestMatchResourceAttr("ably_api_key.key0", "key_name", regexp.MustCompile(`^[\w-]+\.[\w-]+$`)),
+					resource.TestCheckResourceAttrSet("ably_api_key.key0", "key_secret"),
 					resource.TestCheckResourceAttrWith("ably_api_key.key0", "key", func(value string) error {
 						if value == "" {
 							return fmt.Errorf("key can't be empty")
@@ -58,6 +63,104 @@ func TestAccAblyKey(t *testing.T) {
 	})
 }
 
+// Test that a key revoked outside of Terraform is kept in the state and replaced by the next apply
+func TestAccAblyKeyRevoked(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	key_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	var app_id, key_id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyKeyConfig(app_name, key_name, "channel100", `["publish"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_api_key.key0", "revoked", "false"),
+					resource.TestCheckResourceAttrWith("ably_api_key.key0", "app_id", func(value string) error {
+						app_id = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("ably_api_key.key0", "id", func(value string) error {
+						key_id = value
+						return nil
+					}),
+				),
+			},
+			// Revoking the key outside of Terraform plans its replacement
+			{
+				PreConfig: func() {
+					url := os.Getenv("ABLY_URL")
+					if url == "" {
+						url = CONTROL_API_DEFAULT_URL
+					}
+					client, _, err := ably_control_go.NewClientWithURL(os.Getenv("ABLY_ACCOUNT_TOKEN"), url)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := client.RevokeKey(app_id, key_id); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccAblyKeyConfig(app_name, key_name, "channel100", `["publish"]`, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAblyKeyConfig(app_name, key_name, "channel100", `["publish"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_api_key.key0", "revoked", "false"),
+					resource.TestCheckResourceAttr("ably_api_key.key0", "status", "0"),
+					resource.TestCheckResourceAttrWith("ably_api_key.key0", "id", func(value string) error {
+						if value == key_id {
+							return fmt.Errorf("revoked key %s was not replaced", key_id)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+// Test Create and Update of an Ably Key with capability blocks, and plan time validation of operations
+func TestAccAblyKeyCapabilityBlocks(t *testing.T) {
+	app_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	key_name := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAblyKeyCapabilityBlocksConfig(app_name, key_name, `["publsh"]`),
+				ExpectError: regexp.MustCompile(`operation on "channel100" must be one of`),
+			},
+			{
+				Config: testAccAblyKeyCapabilityBlocksConfig(app_name, key_name, `["publish", "subscribe"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_api_key.key0", "capability.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_api_key.key0", "capability.*", map[string]string{
+						"resource": "channel100",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_api_key.key0", "capability.*", map[string]string{
+						"resource":     "[queue]*",
+						"operations.0": "subscribe",
+					}),
+					resource.TestCheckNoResourceAttr("ably_api_key.key0", "capabilities.%"),
+				),
+			},
+			{
+				Config: testAccAblyKeyCapabilityBlocksConfig(app_name, key_name, `["history"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("ably_api_key.key0", "capability.*", map[string]string{
+						"resource":     "channel100",
+						"operations.0": "history",
+					}),
+				),
+			},
+		},
+	})
+}
+
 // Function with inline HCL to provision an ably_app resource
 // Takes App name, Key Name, Capability Name and Capability List as function params.
 func testAccAblyKeyConfig(appName string, keyName string, keyCapabilityName0 string, keyCapabilityCap0 string, revocableTokens bool) string {
@@ -74,9 +177,10 @@ terraform {
 provider "ably" {}
 	  
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_api_key" "key0" {
@@ -89,3 +193,42 @@ resource "ably_api_key" "key0" {
   }
 `, appName, keyName, keyCapabilityName0, keyCapabilityCap0, revocableTokens)
 }
+
+// Function with inline HCL to provision an ably_api_key resource with capability blocks
+// Takes App name, Key Name and the operations on channel100 as function params.
+func testAccAblyKeyCapabilityBlocksConfig(appName string, keyName string, channelOperations string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_api_key" "key0" {
+	app_id = ably_app.app0.id
+	name   = %[2]q
+
+	capability {
+		resource   = "channel100"
+		operations = %[3]s
+	}
+
+	capability {
+		resource   = "[queue]*"
+		operations = ["subscribe"]
+	}
+}
+`, appName, keyName, channelOperations)
+}
@@ -2,122 +2,158 @@ package ably_control
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	ably_control_go "github.com/ably/ably-control-go"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Batching policies and interval range (in milliseconds) accepted by Ably. The
+// first policy is the default.
+var namespaceBatchingPolicies = []string{"simple"}
+
+const (
+	namespaceBatchingIntervalMin = 20
+	namespaceBatchingIntervalMax = 1000
+)
+
 type resourceNamespace struct {
 	p *provider
 }
 
 // Get Namespace Resource schema
-func (r resourceNamespace) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes: map[string]tfsdk.Attribute{
-			"app_id": {
-				Type:        types.StringType,
+func (r resourceNamespace) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The application ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"id": {
-				Type:        types.StringType,
+			"id": schema.StringAttribute{
 				Required:    true,
 				Description: "The namespace or channel name that the channel rule will apply to.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"authenticated": {
-				Type:        types.BoolType,
+			"authenticated": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "Require clients to be authenticated to use channels in this namespace.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"persisted": {
-				Type:        types.BoolType,
+			"persisted": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, messages will be stored for 24 hours.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"persist_last": {
-				Type:        types.BoolType,
+			"persist_last": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, the last message on each channel will persist for 365 days.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"push_enabled": {
-				Type:        types.BoolType,
+			"push_enabled": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, publishing messages with a push payload in the extras field is permitted.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"tls_only": {
-				Type:        types.BoolType,
+			"tls_only": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, only clients that are connected using TLS will be permitted to subscribe.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"expose_timeserial": {
-				Type:        types.BoolType,
+			"expose_timeserial": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, messages received on a channel will contain a unique timeserial that can be referenced by later messages for use with message interactions.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"batching_enabled": {
-				Type:        types.BoolType,
+			"batching_enabled": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "If true, channels within this namespace will start batching inbound messages instead of sending them out immediately to subscribers as per the configured policy.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.Bool{
 					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"batching_policy": {
-				Type:        types.StringType,
+			"batching_policy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "When configured, sets the policy for message batching. The only supported policy is `simple`. Requires `batching_enabled` to be true.",
+				PlanModifiers: []planmodifier.String{
+					DefaultAttribute(types.StringNull()),
+				},
+			},
+			"batching_interval": schema.Int64Attribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "When configured, sets the policy for message batching.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					DefaultAttribute(types.StringValue("")),
+				Description: "When configured, sets the maximium batching interval in the channel, in milliseconds. Must be between 20 and 1000. Requires `batching_enabled` to be true.",
+				PlanModifiers: []planmodifier.Int64{
+					DefaultAttribute(types.Int64Null()),
+				},
+			},
+			"conflation_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "If true, channels within this namespace will conflate messages, only sending subscribers the latest message for each conflation key in each interval.",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
 				},
 			},
-			"batching_interval": {
-				Type:        types.Int64Type,
+			"conflation_interval": schema.Int64Attribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "When configured, sets the maximium batching interval in the channel.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				Description: "When configured, sets the conflation interval in the channel, in milliseconds. Must be greater than 0. Requires `conflation_enabled` to be true.",
+				PlanModifiers: []planmodifier.Int64{
 					DefaultAttribute(types.Int64Null()),
 				},
 			},
+			"conflation_key": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "When configured, sets the key used to determine which messages are conflated, for example `#{message.name}`. Requires `conflation_enabled` to be true.",
+				PlanModifiers: []planmodifier.String{
+					DefaultAttribute(types.StringNull()),
+				},
+			},
+			"populate_channel_registry": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "If true, channels within this namespace will be added to the channel registry, so that they can be enumerated.",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
+				},
+			},
 		},
 		MarkdownDescription: "The ably_namespace resource allows you to manage namespaces for channel rules in Ably. Read more in the Ably documentation: https://ably.com/docs/general/channel-rules-namespaces.",
-	}, nil
+	}
 }
 
 // Create a new resource
@@ -140,24 +176,25 @@ func (r resourceNamespace) Create(ctx context.Context, req tfsdk_resource.Create
 	}
 
 	// Generates an API request body from the plan values
-	namespace_values := ably_control_go.Namespace{
-		ID:               plan.ID.ValueString(),
-		Authenticated:    plan.Authenticated.ValueBool(),
-		Persisted:        plan.Persisted.ValueBool(),
-		PersistLast:      plan.PersistLast.ValueBool(),
-		PushEnabled:      plan.PushEnabled.ValueBool(),
-		TlsOnly:          plan.TlsOnly.ValueBool(),
-		ExposeTimeserial: plan.ExposeTimeserial.ValueBool(),
+	namespace_values := namespaceChannelRules{
+		Namespace: ably_control_go.Namespace{
+			ID:               plan.ID.ValueString(),
+			Authenticated:    plan.Authenticated.ValueBool(),
+			Persisted:        plan.Persisted.ValueBool(),
+			PersistLast:      plan.PersistLast.ValueBool(),
+			PushEnabled:      plan.PushEnabled.ValueBool(),
+			TlsOnly:          plan.TlsOnly.ValueBool(),
+			ExposeTimeserial: plan.ExposeTimeserial.ValueBool(),
+		},
+		PopulateChannelRegistry: plan.PopulateChannelRegistry.ValueBool(),
 	}
 
-	if plan.BatchingEnabled.ValueBool() {
-		namespace_values.BatchingEnabled = true
-		namespace_values.BatchingPolicy = plan.BatchingPolicy.ValueString()
-		namespace_values.BatchingInterval = ably_control_go.BatchingInterval(int(plan.BatchingInterval.ValueInt64()))
-	}
+	SetNamespaceBatching(&namespace_values.Namespace, plan.BatchingEnabled, plan.BatchingPolicy, plan.BatchingInterval)
+	SetNamespaceConflation(&namespace_values, plan.ConflationEnabled, plan.ConflationInterval, plan.ConflationKey)
 
-	// Creates a new Ably namespace by invoking the CreateNamespace function from the Client Library
-	ably_namespace, err := r.p.client.CreateNamespace(plan.AppID.ValueString(), &namespace_values)
+	// Creates a new Ably namespace. The Client Library does not support the conflation and
+	// channel registry settings, so the request is sent with the shared request helper.
+	ably_namespace, err := r.p.createNamespaceChannelRules(plan.AppID.ValueString(), &namespace_values)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Resource",
@@ -166,25 +203,26 @@ func (r resourceNamespace) Create(ctx context.Context, req tfsdk_resource.Create
 		return
 	}
 
-	// Handle the pointer gracefully
-	batchingInterval := types.Int64Null()
-	if ably_namespace.BatchingInterval != nil {
-		batchingInterval = types.Int64Value(int64(*ably_namespace.BatchingInterval))
-	}
+	batchingPolicy, batchingInterval := GetNamespaceBatchingResponse(&ably_namespace.Namespace, plan.BatchingPolicy, plan.BatchingInterval)
+	conflationInterval, conflationKey := GetNamespaceConflationResponse(&ably_namespace, plan.ConflationInterval, plan.ConflationKey)
 
 	// Maps response body to resource schema attributes.
 	resp_apps := AblyNamespace{
-		AppID:            types.StringValue(plan.AppID.ValueString()),
-		ID:               types.StringValue(ably_namespace.ID),
-		Authenticated:    types.BoolValue(ably_namespace.Authenticated),
-		Persisted:        types.BoolValue(ably_namespace.Persisted),
-		PersistLast:      types.BoolValue(ably_namespace.PersistLast),
-		PushEnabled:      types.BoolValue(ably_namespace.PushEnabled),
-		TlsOnly:          types.BoolValue(ably_namespace.TlsOnly),
-		ExposeTimeserial: types.BoolValue(namespace_values.ExposeTimeserial),
-		BatchingEnabled:  types.BoolValue(ably_namespace.BatchingEnabled),
-		BatchingPolicy:   types.StringValue(ably_namespace.BatchingPolicy),
-		BatchingInterval: batchingInterval,
+		AppID:                   types.StringValue(plan.AppID.ValueString()),
+		ID:                      types.StringValue(ably_namespace.ID),
+		Authenticated:           types.BoolValue(ably_namespace.Authenticated),
+		Persisted:               types.BoolValue(ably_namespace.Persisted),
+		PersistLast:             types.BoolValue(ably_namespace.PersistLast),
+		PushEnabled:             types.BoolValue(ably_namespace.PushEnabled),
+		TlsOnly:                 types.BoolValue(ably_namespace.TlsOnly),
+		ExposeTimeserial:        types.BoolValue(namespace_values.ExposeTimeserial),
+		BatchingEnabled:         types.BoolValue(ably_namespace.BatchingEnabled),
+		BatchingPolicy:          batchingPolicy,
+		BatchingInterval:        batchingInterval,
+		ConflationEnabled:       types.BoolValue(ably_namespace.ConflationEnabled),
+		ConflationInterval:      conflationInterval,
+		ConflationKey:           conflationKey,
+		PopulateChannelRegistry: types.BoolValue(ably_namespace.PopulateChannelRegistry),
 	}
 
 	// Sets state for the new Ably App.
@@ -195,6 +233,21 @@ func (r resourceNamespace) Create(ctx context.Context, req tfsdk_resource.Create
 	}
 }
 
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceNamespace{}
+
+// Validates the batching and conflation attributes before planning
+func (r resourceNamespace) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	var config AblyNamespace
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(ValidateNamespaceBatching(path.Empty(), config.BatchingEnabled, config.BatchingPolicy, config.BatchingInterval)...)
+	resp.Diagnostics.Append(ValidateNamespaceConflation(path.Empty(), config.ConflationEnabled, config.ConflationInterval, config.ConflationKey)...)
+}
+
 func (r resourceNamespace) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
 	resp.TypeName = "ably_namespace"
 }
@@ -215,9 +268,9 @@ func (r resourceNamespace) Read(ctx context.Context, req tfsdk_resource.ReadRequ
 	app_id := state.AppID.ValueString()
 	namespace_id := state.ID.ValueString()
 
-	// Fetches all Ably Namespaces in the app. The function invokes the Client Library Namespaces() method.
+	// Fetches all Ably Namespaces in the app, including their conflation and channel registry settings.
 	// NOTE: Control API & Client Lib do not currently support fetching single namespace given namespace id
-	namespaces, err := r.p.client.Namespaces(app_id)
+	namespaces, err := r.p.namespaceChannelRules(app_id)
 	if err != nil {
 		if is_404(err) {
 			resp.State.RemoveResource(ctx)
@@ -233,24 +286,34 @@ func (r resourceNamespace) Read(ctx context.Context, req tfsdk_resource.ReadRequ
 	// Loops through namespaces and if id matches, sets state.
 	for _, v := range namespaces {
 		if v.ID == namespace_id {
-			// Handle the pointer gracefully
-			batchingInterval := types.Int64Null()
-			if v.BatchingInterval != nil {
-				batchingInterval = types.Int64Value(int64(*v.BatchingInterval))
+			// Imported namespaces have no prior batching or conflation state
+			prior_policy, prior_interval := state.BatchingPolicy, state.BatchingInterval
+			if state.BatchingEnabled.IsNull() {
+				prior_policy, prior_interval = types.StringUnknown(), types.Int64Unknown()
+			}
+			prior_conflation_interval, prior_conflation_key := state.ConflationInterval, state.ConflationKey
+			if state.ConflationEnabled.IsNull() {
+				prior_conflation_interval, prior_conflation_key = types.Int64Unknown(), types.StringUnknown()
 			}
+			batchingPolicy, batchingInterval := GetNamespaceBatchingResponse(&v.Namespace, prior_policy, prior_interval)
+			conflationInterval, conflationKey := GetNamespaceConflationResponse(&v, prior_conflation_interval, prior_conflation_key)
 
 			resp_namespaces := AblyNamespace{
-				AppID:            types.StringValue(app_id),
-				ID:               types.StringValue(namespace_id),
-				Authenticated:    types.BoolValue(v.Authenticated),
-				Persisted:        types.BoolValue(v.Persisted),
-				PersistLast:      types.BoolValue(v.PersistLast),
-				PushEnabled:      types.BoolValue(v.PushEnabled),
-				TlsOnly:          types.BoolValue(v.TlsOnly),
-				ExposeTimeserial: types.BoolValue(v.ExposeTimeserial),
-				BatchingEnabled:  types.BoolValue(v.BatchingEnabled),
-				BatchingPolicy:   types.StringValue(v.BatchingPolicy),
-				BatchingInterval: batchingInterval,
+				AppID:                   types.StringValue(app_id),
+				ID:                      types.StringValue(namespace_id),
+				Authenticated:           types.BoolValue(v.Authenticated),
+				Persisted:               types.BoolValue(v.Persisted),
+				PersistLast:             types.BoolValue(v.PersistLast),
+				PushEnabled:             types.BoolValue(v.PushEnabled),
+				TlsOnly:                 types.BoolValue(v.TlsOnly),
+				ExposeTimeserial:        types.BoolValue(v.ExposeTimeserial),
+				BatchingEnabled:         types.BoolValue(v.BatchingEnabled),
+				BatchingPolicy:          batchingPolicy,
+				BatchingInterval:        batchingInterval,
+				ConflationEnabled:       types.BoolValue(v.ConflationEnabled),
+				ConflationInterval:      conflationInterval,
+				ConflationKey:           conflationKey,
+				PopulateChannelRegistry: types.BoolValue(v.PopulateChannelRegistry),
 			}
 			// Sets state to namespace values.
 			diags = resp.State.Set(ctx, &resp_namespaces)
@@ -284,24 +347,24 @@ func (r resourceNamespace) Update(ctx context.Context, req tfsdk_resource.Update
 	namespace_id := plan.ID.ValueString()
 
 	// Instantiates struct of type ably_control_go.Namespace and sets values to output of plan
-	namespace_values := ably_control_go.Namespace{
-		ID:               namespace_id,
-		Authenticated:    plan.Authenticated.ValueBool(),
-		Persisted:        plan.Persisted.ValueBool(),
-		PersistLast:      plan.PersistLast.ValueBool(),
-		PushEnabled:      plan.PushEnabled.ValueBool(),
-		TlsOnly:          plan.TlsOnly.ValueBool(),
-		ExposeTimeserial: plan.ExposeTimeserial.ValueBool(),
+	namespace_values := namespaceChannelRules{
+		Namespace: ably_control_go.Namespace{
+			ID:               namespace_id,
+			Authenticated:    plan.Authenticated.ValueBool(),
+			Persisted:        plan.Persisted.ValueBool(),
+			PersistLast:      plan.PersistLast.ValueBool(),
+			PushEnabled:      plan.PushEnabled.ValueBool(),
+			TlsOnly:          plan.TlsOnly.ValueBool(),
+			ExposeTimeserial: plan.ExposeTimeserial.ValueBool(),
+		},
+		PopulateChannelRegistry: plan.PopulateChannelRegistry.ValueBool(),
 	}
 
-	if plan.BatchingEnabled.ValueBool() {
-		namespace_values.BatchingEnabled = true
-		namespace_values.BatchingPolicy = plan.BatchingPolicy.ValueString()
-		namespace_values.BatchingInterval = ably_control_go.BatchingInterval(int(plan.BatchingInterval.ValueInt64()))
-	}
+	SetNamespaceBatching(&namespace_values.Namespace, plan.BatchingEnabled, plan.BatchingPolicy, plan.BatchingInterval)
+	SetNamespaceConflation(&namespace_values, plan.ConflationEnabled, plan.ConflationInterval, plan.ConflationKey)
 
-	// Updates an Ably Namespace. The function invokes the Client Library UpdateNamespace method.
-	ably_namespace, err := r.p.client.UpdateNamespace(app_id, &namespace_values)
+	// Updates an Ably Namespace, including the conflation and channel registry settings.
+	ably_namespace, err := r.p.updateNamespaceChannelRules(app_id, &namespace_values)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Resource",
@@ -310,24 +373,25 @@ func (r resourceNamespace) Update(ctx context.Context, req tfsdk_resource.Update
 		return
 	}
 
-	// Handle the pointer gracefully
-	batchingInterval := types.Int64Null()
-	if ably_namespace.BatchingInterval != nil {
-		batchingInterval = types.Int64Value(int64(*ably_namespace.BatchingInterval))
-	}
+	batchingPolicy, batchingInterval := GetNamespaceBatchingResponse(&ably_namespace.Namespace, plan.BatchingPolicy, plan.BatchingInterval)
+	conflationInterval, conflationKey := GetNamespaceConflationResponse(&ably_namespace, plan.ConflationInterval, plan.ConflationKey)
 
 	resp_namespaces := AblyNamespace{
-		AppID:            types.StringValue(app_id),
-		ID:               types.StringValue(ably_namespace.ID),
-		Authenticated:    types.BoolValue(ably_namespace.Authenticated),
-		Persisted:        types.BoolValue(ably_namespace.Persisted),
-		PersistLast:      types.BoolValue(ably_namespace.PersistLast),
-		PushEnabled:      types.BoolValue(ably_namespace.PushEnabled),
-		TlsOnly:          types.BoolValue(ably_namespace.TlsOnly),
-		ExposeTimeserial: types.BoolValue(ably_namespace.ExposeTimeserial),
-		BatchingEnabled:  types.BoolValue(ably_namespace.BatchingEnabled),
-		BatchingPolicy:   types.StringValue(ably_namespace.BatchingPolicy),
-		BatchingInterval: batchingInterval,
+		AppID:                   types.StringValue(app_id),
+		ID:                      types.StringValue(ably_namespace.ID),
+		Authenticated:           types.BoolValue(ably_namespace.Authenticated),
+		Persisted:               types.BoolValue(ably_namespace.Persisted),
+		PersistLast:             types.BoolValue(ably_namespace.PersistLast),
+		PushEnabled:             types.BoolValue(ably_namespace.PushEnabled),
+		TlsOnly:                 types.BoolValue(ably_namespace.TlsOnly),
+		ExposeTimeserial:        types.BoolValue(ably_namespace.ExposeTimeserial),
+		BatchingEnabled:         types.BoolValue(ably_namespace.BatchingEnabled),
+		BatchingPolicy:          batchingPolicy,
+		BatchingInterval:        batchingInterval,
+		ConflationEnabled:       types.BoolValue(ably_namespace.ConflationEnabled),
+		ConflationInterval:      conflationInterval,
+		ConflationKey:           conflationKey,
+		PopulateChannelRegistry: types.BoolValue(ably_namespace.PopulateChannelRegistry),
 	}
 
 	// Sets state to new namespace.
@@ -372,6 +436,168 @@ func (r resourceNamespace) Delete(ctx context.Context, req tfsdk_resource.Delete
 	resp.State.RemoveResource(ctx)
 }
 
+// Sets the batching fields of a namespace request. Batching fields are only sent
+// when batching is enabled. The client always sends the policy, so the default
+// policy is used when it is not configured.
+func SetNamespaceBatching(namespace *ably_control_go.Namespace, enabled types.Bool, policy types.String, interval types.Int64) {
+	if !enabled.ValueBool() {
+		return
+	}
+
+	namespace.BatchingEnabled = true
+	namespace.BatchingPolicy = namespaceBatchingPolicies[0]
+	if !policy.IsNull() {
+		namespace.BatchingPolicy = policy.ValueString()
+	}
+	if !interval.IsNull() {
+		namespace.BatchingInterval = ably_control_go.BatchingInterval(int(interval.ValueInt64()))
+	}
+}
+
+// Maps the batching fields of a namespace response. The Control API returns
+// defaults for the policy and interval even when batching is disabled or they
+// are not configured, so these are normalised to null when batching is disabled
+// or the prior plan or state value is null. Prior values are unknown when there
+// is no prior state, such as for imported or unmanaged namespaces, in which case
+// the values returned by the Control API are used.
+func GetNamespaceBatchingResponse(namespace *ably_control_go.Namespace, prior_policy types.String, prior_interval types.Int64) (types.String, types.Int64) {
+	if !namespace.BatchingEnabled {
+		return types.StringNull(), types.Int64Null()
+	}
+
+	policy := types.StringNull()
+	if !prior_policy.IsNull() {
+		policy = types.StringValue(namespace.BatchingPolicy)
+		emptyStringToNull(&policy)
+	}
+
+	// Handle the pointer gracefully
+	interval := types.Int64Null()
+	if !prior_interval.IsNull() && namespace.BatchingInterval != nil {
+		interval = types.Int64Value(int64(*namespace.BatchingInterval))
+	}
+
+	return policy, interval
+}
+
+// Validates the batching attributes of a namespace found at the given path.
+func ValidateNamespaceBatching(p path.Path, enabled types.Bool, policy types.String, interval types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if enabled.IsUnknown() {
+		return diags
+	}
+
+	if !enabled.ValueBool() {
+		if !policy.IsNull() {
+			diags.AddAttributeError(
+				p.AtName("batching_policy"),
+				"Invalid Attribute Combination",
+				"batching_policy can only be set when batching_enabled is true",
+			)
+		}
+		if !interval.IsNull() {
+			diags.AddAttributeError(
+				p.AtName("batching_interval"),
+				"Invalid Attribute Combination",
+				"batching_interval can only be set when batching_enabled is true",
+			)
+		}
+		return diags
+	}
+
+	if !policy.IsNull() && !policy.IsUnknown() && !slices.Contains(namespaceBatchingPolicies, policy.ValueString()) {
+		diags.AddAttributeError(
+			p.AtName("batching_policy"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("batching_policy must be one of %s, got: %q", strings.Join(namespaceBatchingPolicies, ", "), policy.ValueString()),
+		)
+	}
+
+	if !interval.IsNull() && !interval.IsUnknown() && (interval.ValueInt64() < namespaceBatchingIntervalMin || interval.ValueInt64() > namespaceBatchingIntervalMax) {
+		diags.AddAttributeError(
+			p.AtName("batching_interval"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("batching_interval must be between %d and %d milliseconds, got: %d", namespaceBatchingIntervalMin, namespaceBatchingIntervalMax, interval.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
+// Sets the conflation fields of a namespace request. Conflation fields are only sent
+// when conflation is enabled.
+func SetNamespaceConflation(namespace *namespaceChannelRules, enabled types.Bool, interval types.Int64, key types.String) {
+	if !enabled.ValueBool() {
+		return
+	}
+
+	namespace.ConflationEnabled = true
+	if !interval.IsNull() {
+		conflation_interval := int(interval.ValueInt64())
+		namespace.ConflationInterval = &conflation_interval
+	}
+	namespace.ConflationKey = key.ValueString()
+}
+
+// Maps the conflation fields of a namespace response in the same way as the batching
+// fields, see GetNamespaceBatchingResponse.
+func GetNamespaceConflationResponse(namespace *namespaceChannelRules, prior_interval types.Int64, prior_key types.String) (types.Int64, types.String) {
+	if !namespace.ConflationEnabled {
+		return types.Int64Null(), types.StringNull()
+	}
+
+	interval := types.Int64Null()
+	if !prior_interval.IsNull() && namespace.ConflationInterval != nil {
+		interval = types.Int64Value(int64(*namespace.ConflationInterval))
+	}
+
+	key := types.StringNull()
+	if !prior_key.IsNull() {
+		key = types.StringValue(namespace.ConflationKey)
+		emptyStringToNull(&key)
+	}
+
+	return interval, key
+}
+
+// Validates the conflation attributes of a namespace found at the given path.
+func ValidateNamespaceConflation(p path.Path, enabled types.Bool, interval types.Int64, key types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if enabled.IsUnknown() {
+		return diags
+	}
+
+	if !enabled.ValueBool() {
+		if !interval.IsNull() {
+			diags.AddAttributeError(
+				p.AtName("conflation_interval"),
+				"Invalid Attribute Combination",
+				"conflation_interval can only be set when conflation_enabled is true",
+			)
+		}
+		if !key.IsNull() {
+			diags.AddAttributeError(
+				p.AtName("conflation_key"),
+				"Invalid Attribute Combination",
+				"conflation_key can only be set when conflation_enabled is true",
+			)
+		}
+		return diags
+	}
+
+	if !interval.IsNull() && !interval.IsUnknown() && interval.ValueInt64() <= 0 {
+		diags.AddAttributeError(
+			p.AtName("conflation_interval"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("conflation_interval must be greater than 0 milliseconds, got: %d", interval.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
 // Import resource
 func (r resourceNamespace) ImportState(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse) {
 	ImportResource(ctx, req, resp, "app_id", "id")
@@ -2,11 +2,15 @@ package ably_control
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccAblyNamespace(t *testing.T) {
@@ -108,11 +112,77 @@ func TestAccAblyNamespace(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_namespace.namespace0", "batching_interval", "100"),
 				),
 			},
+			// Import testing of ably_namespace.namespace0, including the batching settings
+			{
+				ResourceName:      "ably_namespace.namespace0",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAblyNamespaceImportStateId("ably_namespace.namespace0"),
+				ImportStateVerify: true,
+			},
+			// Batching can be enabled without configuring the policy or interval
+			{
+				Config: testAccAblyNamespaceBatchingEnabledConfig(app_name, namespace_name+"batching"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "id", namespace_name+"batching"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "batching_enabled", "true"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "batching_policy"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "batching_interval"),
+				),
+			},
+			// Conflation and channel registry settings
+			{
+				Config: testAccAblyNamespaceConflationConfig(app_name, namespace_name+"batching"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "id", namespace_name+"batching"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "conflation_enabled", "true"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "conflation_interval", "1000"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "conflation_key", "#{message.name}"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "populate_channel_registry", "true"),
+				),
+			},
+			// Conflation settings can only be set when conflation is enabled
+			{
+				Config:      testAccAblyNamespaceConflationDisabledConfig(app_name, namespace_name+"batching"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Disabling batching clears the batching policy and interval
+			{
+				Config: testAccAblyNamespaceConfig(app_name, ably_control_go.Namespace{
+					ID:               namespace_name + "batching",
+					Authenticated:    false,
+					Persisted:        false,
+					PersistLast:      false,
+					PushEnabled:      false,
+					TlsOnly:          false,
+					ExposeTimeserial: false,
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "id", namespace_name+"batching"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "batching_enabled", "false"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "batching_policy"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "batching_interval"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "conflation_enabled", "false"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "conflation_interval"),
+					resource.TestCheckNoResourceAttr("ably_namespace.namespace0", "conflation_key"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "populate_channel_registry", "false"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
+// Gets the import identifier of a namespace, in the format app_id,id
+func testAccAblyNamespaceImportStateId(resource_name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resource_name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resource_name)
+		}
+		return rs.Primary.Attributes["app_id"] + "," + rs.Primary.Attributes["id"], nil
+	}
+}
+
 // Function with inline HCL to provision an ably_app resource
 // Takes App name, status and tls_only status as function params.
 func testAccAblyNamespaceConfig(appName string, namespace ably_control_go.Namespace) string {
@@ -129,9 +199,10 @@ terraform {
 provider "ably" {}
 
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_namespace" "namespace0" {
@@ -163,9 +234,10 @@ terraform {
 provider "ably" {}
 
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_namespace" "namespace0" {
@@ -184,3 +256,150 @@ resource "ably_namespace" "namespace0" {
 
 `, appName, namespace.ID, namespace.Authenticated, namespace.Persisted, namespace.PersistLast, namespace.PushEnabled, namespace.TlsOnly, namespace.ExposeTimeserial, namespace.BatchingEnabled, namespace.BatchingPolicy, *namespace.BatchingInterval)
 }
+
+func testAccAblyNamespaceBatchingEnabledConfig(appName string, namespaceID string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_namespace" "namespace0" {
+  app_id           = ably_app.app0.id
+  id               = %[2]q
+  batching_enabled = true
+}
+
+`, appName, namespaceID)
+}
+
+func testAccAblyNamespaceConflationConfig(appName string, namespaceID string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_namespace" "namespace0" {
+  app_id                    = ably_app.app0.id
+  id                        = %[2]q
+  conflation_enabled        = true
+  conflation_interval       = 1000
+  conflation_key            = "#{message.name}"
+  populate_channel_registry = true
+}
+
+`, appName, namespaceID)
+}
+
+func testAccAblyNamespaceConflationDisabledConfig(appName string, namespaceID string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+		source = "github.com/ably/ably"
+		}
+	}
+}
+
+# You can provide your Ably Token & URL inline or use environment variables ABLY_ACCOUNT_TOKEN & ABLY_URL
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
+}
+
+resource "ably_namespace" "namespace0" {
+  app_id         = ably_app.app0.id
+  id             = %[2]q
+  conflation_key = "#{message.name}"
+}
+
+`, appName, namespaceID)
+}
+
+func TestGetNamespaceBatchingResponse(t *testing.T) {
+	namespace := ably_control_go.Namespace{
+		BatchingEnabled:  true,
+		BatchingPolicy:   "simple",
+		BatchingInterval: ably_control_go.BatchingInterval(100),
+	}
+
+	// Imported and unmanaged namespaces have no prior values
+	policy, interval := GetNamespaceBatchingResponse(&namespace, types.StringUnknown(), types.Int64Unknown())
+	if policy.ValueString() != "simple" || interval.ValueInt64() != 100 {
+		t.Errorf("expected the batching settings of the namespace, got: %s, %s", policy, interval)
+	}
+
+	// Values left unset in the configuration stay unset
+	policy, interval = GetNamespaceBatchingResponse(&namespace, types.StringNull(), types.Int64Null())
+	if !policy.IsNull() || !interval.IsNull() {
+		t.Errorf("expected nulls for unset batching settings, got: %s, %s", policy, interval)
+	}
+
+	namespace.BatchingEnabled = false
+	policy, interval = GetNamespaceBatchingResponse(&namespace, types.StringUnknown(), types.Int64Unknown())
+	if !policy.IsNull() || !interval.IsNull() {
+		t.Errorf("expected nulls when batching is disabled, got: %s, %s", policy, interval)
+	}
+}
+
+func TestValidateNamespaceConflation(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  types.Bool
+		interval types.Int64
+		key      types.String
+		summary  string
+	}{
+		{name: "enabled", enabled: types.BoolValue(true), interval: types.Int64Value(1000), key: types.StringValue("#{message.name}")},
+		{name: "enabled without interval or key", enabled: types.BoolValue(true), interval: types.Int64Null(), key: types.StringNull()},
+		{name: "disabled with interval", enabled: types.BoolValue(false), interval: types.Int64Value(1000), key: types.StringNull(), summary: "Invalid Attribute Combination"},
+		{name: "disabled with key", enabled: types.BoolNull(), interval: types.Int64Null(), key: types.StringValue("#{message.name}"), summary: "Invalid Attribute Combination"},
+		{name: "invalid interval", enabled: types.BoolValue(true), interval: types.Int64Value(0), key: types.StringNull(), summary: "Invalid Attribute Value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := ValidateNamespaceConflation(path.Empty(), tt.enabled, tt.interval, tt.key)
+			if tt.summary == "" {
+				if diags.HasError() {
+					t.Fatalf("expected no errors, got: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Summary() != tt.summary {
+				t.Fatalf("expected %q, got: %v", tt.summary, diags)
+			}
+		})
+	}
+}
@@ -3,134 +3,222 @@ package ably_control
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	ably_control_go "github.com/ably/ably-control-go"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
-
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Regions in which queues can be provisioned.
+// NOTE: Regions should be discovered from the Control API with this list as a fallback, but the
+// Control API has no endpoint which lists queue regions. Until it does, this list has to be
+// updated when Ably adds a region, and regions missing from it are rejected at plan time.
+var queueRegions = []string{
+	string(ably_control_go.UsEast1A),
+	string(ably_control_go.EuWest1A),
+}
+
 type resourceQueue struct {
 	p *provider
 }
 
 // Get Queue Resource schema
-func (r resourceQueue) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes: map[string]tfsdk.Attribute{
-			"app_id": {
-				Type:        types.StringType,
+func (r resourceQueue) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The application ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"id": {
-				Type:        types.StringType,
+			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The ID of the queue",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"name": {
-				Type:        types.StringType,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"ttl": {
-				Type:        types.Int64Type,
+			"ttl": schema.Int64Attribute{
 				Required:    true,
 				Description: "Time to live in minutes.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
-			"max_length": {
-				Type:        types.Int64Type,
+			"max_length": schema.Int64Attribute{
 				Required:    true,
 				Description: "Message limit in number of messages.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
-			"region": {
-				Type:        types.StringType,
+			"region": schema.StringAttribute{
 				Required:    true,
 				Description: "The data center region. US East (Virginia) or EU West (Ireland). Values are us-east-1-a or eu-west-1-a.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
-			"amqp_uri": {
-				Type:        types.StringType,
+			"amqp_uri": schema.StringAttribute{
 				Computed:    true,
 				Description: "URI for the AMQP queue interface.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"amqp_queue_name": {
-				Type:        types.StringType,
+			"amqp_queue_name": schema.StringAttribute{
 				Computed:    true,
 				Description: "Name of the Ably queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"stomp_uri": {
-				Type:        types.StringType,
+			"stomp_uri": schema.StringAttribute{
 				Computed:    true,
 				Description: "URI for the STOMP queue interface.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"stomp_host": {
-				Type:        types.StringType,
+			"stomp_host": schema.StringAttribute{
 				Computed:    true,
 				Description: "The host type for the queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"stomp_destination": {
-				Type:        types.StringType,
+			"stomp_destination": schema.StringAttribute{
 				Computed:    true,
 				Description: "Destination queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"state": {
-				Type:        types.StringType,
+			"state": schema.StringAttribute{
 				Computed:    true,
 				Description: "The current state of the queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"messages_ready": {
-				Type:        types.Int64Type,
+			"messages_ready": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The number of ready messages in the queue.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"messages_unacknowledged": {
-				Type:        types.Int64Type,
+			"messages_unacknowledged": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The number of unacknowledged messages in the queue.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"messages_total": {
-				Type:        types.Int64Type,
+			"messages_total": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The total number of messages in the queue.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"stats_publish_rate": {
-				Type:        types.Float64Type,
+			"stats_publish_rate": schema.Float64Attribute{
 				Computed:    true,
 				Description: "The rate at which messages are published to the queue. Rate is messages per minute.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"stats_delivery_rate": {
-				Type:        types.Float64Type,
+			"stats_delivery_rate": schema.Float64Attribute{
 				Computed:    true,
 				Description: "The rate at which messages are delivered from the queue. Rate is messages per minute.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"stats_acknowledgement_rate": {
-				Type:        types.Float64Type,
+			"stats_acknowledgement_rate": schema.Float64Attribute{
 				Computed:    true,
 				Description: "The rate at which messages are acknowledged. Rate is messages per minute.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"deadletter": {
-				Type:        types.BoolType,
+			"deadletter": schema.BoolAttribute{
 				Computed:    true,
 				Description: "A boolean that indicates whether this is a dead letter queue or not.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"deadletter_id": {
-				Type:        types.StringType,
+			"deadletter_id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The ID of the dead letter queue.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": GetDeletionProtectionSchema(false),
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Allows the queue to be destroyed or replaced while it still holds ready or unacknowledged messages, which are discarded. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					DefaultAttribute(types.BoolValue(false)),
+				},
 			},
 		},
 		MarkdownDescription: "The ably_queue resource allows you to create and manage Ably queues. Read more about Ably queues in Ably documentation: https://ably.com/docs/general/queues.",
-	}, nil
+	}
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceQueue{}
+
+// Validates the region before planning
+func (r resourceQueue) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	var config AblyQueue
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(ValidateQueueRegion(path.Root("region"), config.Region)...)
+}
+
+// Checks the region is one in which queues can be provisioned.
+func ValidateQueueRegion(p path.Path, region types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if region.IsNull() || region.IsUnknown() || slices.Contains(queueRegions, region.ValueString()) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		p,
+		"Invalid Attribute Value",
+		fmt.Sprintf("region must be one of %s, got: %q", strings.Join(queueRegions, ", "), region.ValueString()),
+	)
+	return diags
 }
 
 func (r resourceQueue) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -156,26 +244,12 @@ func (r resourceQueue) Create(ctx context.Context, req tfsdk_resource.CreateRequ
 		return
 	}
 
-	var region ably_control_go.Region
-	switch plan.Region.ValueString() {
-	case string(ably_control_go.UsEast1A):
-		region = ably_control_go.UsEast1A
-	case string(ably_control_go.EuWest1A):
-		region = ably_control_go.EuWest1A
-	default:
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			fmt.Sprintf("Invalid value for Queue.Region '%s'", plan.Region.ValueString()),
-		)
-		return
-	}
-
 	// Generates an API request body from the plan values
 	queue_values := ably_control_go.NewQueue{
 		Name:      plan.Name.ValueString(),
 		Ttl:       int(plan.Ttl.ValueInt64()),
 		MaxLength: int(plan.MaxLength.ValueInt64()),
-		Region:    region,
+		Region:    ably_control_go.Region(plan.Region.ValueString()),
 	}
 
 	// Creates a new Ably queue by invoking the CreateQueue function from the Client Library
@@ -211,6 +285,8 @@ func (r resourceQueue) Create(ctx context.Context, req tfsdk_resource.CreateRequ
 		StatsAcknowledgementRate: types.Float64Value(ably_queue.Stats.AcknowledgementRate),
 		Deadletter:               types.BoolValue(ably_queue.DeadLetter),
 		DeadletterID:             types.StringValue(ably_queue.DeadLetterID),
+		DeletionProtection:       plan.DeletionProtection,
+		ForceDestroy:             plan.ForceDestroy,
 	}
 
 	// Sets state for the new Ably App.
@@ -277,6 +353,8 @@ func (r resourceQueue) Read(ctx context.Context, req tfsdk_resource.ReadRequest,
 				StatsAcknowledgementRate: types.Float64Value(v.Stats.AcknowledgementRate),
 				Deadletter:               types.BoolValue(v.DeadLetter),
 				DeadletterID:             types.StringValue(v.DeadLetterID),
+				DeletionProtection:       state.DeletionProtection,
+				ForceDestroy:             state.ForceDestroy,
 			}
 			// Sets state to queue values.
 			diags = resp.State.Set(ctx, &resp_queues)
@@ -297,12 +375,31 @@ func (r resourceQueue) Read(ctx context.Context, req tfsdk_resource.ReadRequest,
 
 // Update resource
 func (r resourceQueue) Update(ctx context.Context, req tfsdk_resource.UpdateRequest, resp *tfsdk_resource.UpdateResponse) {
-	// This function should never end up being run but needs to exist to satisfy the interface
-	// this error is just in case terraform decides to call it.
-	resp.Diagnostics.AddError(
-		"Error modifying Resource",
-		"Queue can not be modified",
-	)
+	// Queues can not be modified, so every attribute except deletion_protection and
+	// force_destroy, which are only stored in the state, requires replacement.
+	var plan AblyQueue
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state AblyQueue
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DeletionProtection = plan.DeletionProtection
+	state.ForceDestroy = plan.ForceDestroy
+
+	// Sets state to the existing queue with the new deletion protection and force destroy values.
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete resource
@@ -315,10 +412,22 @@ func (r resourceQueue) Delete(ctx context.Context, req tfsdk_resource.DeleteRequ
 		return
 	}
 
+	resp.Diagnostics.Append(CheckDeletionProtection(ctx, req.State, "queue")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Gets the current state. If it is unable to, the provider responds with an error.
 	app_id := state.AppID.ValueString()
 	queue_id := state.ID.ValueString()
 
+	if !state.ForceDestroy.ValueBool() {
+		resp.Diagnostics.Append(r.checkQueueEmpty(app_id, queue_id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	err := r.p.client.DeleteQueue(app_id, queue_id)
 	if err != nil {
 		if is_404(err) {
@@ -342,12 +451,72 @@ func (r resourceQueue) Delete(ctx context.Context, req tfsdk_resource.DeleteRequ
 // Import resource
 func (r resourceQueue) ImportState(ctx context.Context, req tfsdk_resource.ImportStateRequest, resp *tfsdk_resource.ImportStateResponse) {
 	ImportResource(ctx, req, resp, "id")
+	ImportDeletionProtection(ctx, resp, false)
 }
 
 var _ tfsdk_resource.ResourceWithModifyPlan = resourceQueue{}
 
+// Queues can not be updated, so changes to the user settable attributes replace the queue.
+// Runtime values such as message counts and rates keep their state values in the plan.
 func (r resourceQueue) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
-	for k := range req.Plan.Schema.Attributes {
-		resp.RequiresReplace.Append(path.Root(k))
+	replace_paths := []path.Path{
+		path.Root("app_id"),
+		path.Root("name"),
+		path.Root("ttl"),
+		path.Root("max_length"),
+		path.Root("region"),
 	}
+
+	ModifyPlanDeletionProtection(ctx, req, resp, "queue", replace_paths...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Replacing a queue deletes it, so the queue must be empty unless force_destroy is set
+	if IsPlannedReplacement(ctx, req, resp, replace_paths...) {
+		var state AblyQueue
+		diags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !state.ForceDestroy.ValueBool() {
+			resp.Diagnostics.Append(r.checkQueueEmpty(state.AppID.ValueString(), state.ID.ValueString())...)
+		}
+	}
+}
+
+// Checks the queue holds no ready or unacknowledged messages. The queue is re-read as
+// the counts in the state may be out of date.
+func (r resourceQueue) checkQueueEmpty(app_id string, queue_id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !r.p.configured {
+		return diags
+	}
+
+	queues, err := r.p.client.Queues(app_id)
+	if err != nil {
+		if !is_404(err) {
+			diags.AddError(
+				"Error reading Resource",
+				"Could not read queue to check it is empty, unexpected error: "+err.Error(),
+			)
+		}
+		return diags
+	}
+
+	for _, v := range queues {
+		if v.ID == queue_id && (v.Messages.Ready > 0 || v.Messages.Unacknowledged > 0) {
+			diags.AddError(
+				"Queue is not empty",
+				fmt.Sprintf("Cannot delete queue '%s' as it has %d ready and %d unacknowledged messages. "+
+					"Consume the messages or set force_destroy to true and apply the change before destroying or replacing it.",
+					v.Name, v.Messages.Ready, v.Messages.Unacknowledged),
+			)
+		}
+	}
+
+	return diags
 }
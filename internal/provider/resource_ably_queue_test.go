@@ -2,6 +2,7 @@ package ably_control
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	ably_control_go "github.com/ably/ably-control-go"
@@ -16,6 +17,15 @@ func TestAccAblyQueue(t *testing.T) {
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testAccProviders,
 		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyQueueConfig(app_name, ably_control_go.NewQueue{
+					Name:      queue_name,
+					Ttl:       44,
+					MaxLength: 83,
+					Region:    "ap-southeast-2-a",
+				}),
+				ExpectError: regexp.MustCompile(`region must be one of us-east-1-a, eu-west-1-a, got: "ap-southeast-2-a"`),
+			},
 			{
 				Config: testAccAblyQueueConfig(app_name, ably_control_go.NewQueue{
 					Name:      queue_name,
@@ -28,6 +38,7 @@ func TestAccAblyQueue(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_queue.queue0", "name", queue_name),
 					resource.TestCheckResourceAttr("ably_queue.queue0", "ttl", "44"),
 					resource.TestCheckResourceAttr("ably_queue.queue0", "max_length", "83"),
+					resource.TestCheckResourceAttr("ably_queue.queue0", "force_destroy", "false"),
 				),
 			},
 			{
@@ -66,9 +77,10 @@ terraform {
 provider "ably" {}
 
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_queue" "queue0" {
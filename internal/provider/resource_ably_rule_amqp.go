@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleAmqp struct {
@@ -14,19 +12,33 @@ type resourceRuleAmqp struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleAmqp) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"queue_id": {
-				Type:        types.StringType,
+func (r resourceRuleAmqp) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"queue_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of your Ably queue",
 			},
-			"headers":   GetHeaderSchema(),
-			"enveloped": GetEnvelopedSchema(),
-			"format":    GetFormatSchema(),
+			"headers":           GetHeaderSchema(),
+			"sensitive_headers": GetSensitiveHeaderSchema(),
+			"enveloped":         GetEnvelopedSchema(),
+			"format":            GetFormatSchema(),
 		},
-		"The `ably_rule_amqp` resource allows you to create and manage an Ably integration rule for AMQP. Read more at https://ably.com/docs/general/firehose/amqp-rule"), nil
+		"The `ably_rule_amqp` resource allows you to create and manage an Ably integration rule for AMQP. Read more at https://ably.com/docs/general/firehose/amqp-rule")
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceRuleAmqp{}
+
+// Checks header names are unique before planning
+func (r resourceRuleAmqp) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateRuleHeaders(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithUpgradeState = resourceRuleAmqp{}
+
+// Upgrades state with headers saved as lists
+func (r resourceRuleAmqp) UpgradeState(ctx context.Context) map[int64]tfsdk_resource.StateUpgrader {
+	return UpgradeRuleHeadersState()
 }
 
 func (r resourceRuleAmqp) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleAmqpExternal struct {
@@ -14,45 +12,55 @@ type resourceRuleAmqpExternal struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleAmqpExternal) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"url": {
-				Type:        types.StringType,
+func (r resourceRuleAmqpExternal) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"url": schema.StringAttribute{
 				Required:    true,
+				Sensitive:   true,
 				Description: "The webhook URL that Ably will POST events to",
 			},
-			"routing_key": {
-				Type:        types.StringType,
+			"routing_key": schema.StringAttribute{
 				Required:    true,
 				Description: "The Kafka partition key. This is used to determine which partition a message should be routed to, where a topic has been partitioned. routingKey should be in the format topic:key where topic is the topic to publish to, and key is the value to use as the message key",
 			},
-			"exchange": {
-				Type:        types.StringType,
+			"exchange": schema.StringAttribute{
 				Required:    true,
 				Description: "The RabbitMQ exchange, if needed, supports interpolation; see https://faqs.ably.com/what-is-the-format-of-the-routingkey-for-an-amqp-or-kinesis-reactor-rule for more info. If you don't use RabbitMQ exchanges, leave this blank.",
 			},
-			"mandatory_route": {
-				Type:        types.BoolType,
+			"mandatory_route": schema.BoolAttribute{
 				Required:    true,
 				Description: "Reject delivery of the message if the route does not exist, otherwise fail silently.",
 			},
-			"persistent_messages": {
-				Type:        types.BoolType,
+			"persistent_messages": schema.BoolAttribute{
 				Required:    true,
 				Description: "Marks the message as persistent, instructing the broker to write it to disk if it is in a durable queue.",
 			},
-			"message_ttl": {
-				Type:        types.Int64Type,
+			"message_ttl": schema.Int64Attribute{
 				Optional:    true,
 				Description: "You can optionally override the default TTL on a queue and specify a TTL in minutes for messages to be persisted. It is unusual to change the default TTL, so if this field is left empty, the default TTL for the queue will be used.",
 			},
-			"headers":   GetHeaderSchema(),
-			"enveloped": GetEnvelopedSchema(),
-			"format":    GetFormatSchema(),
+			"headers":           GetHeaderSchema(),
+			"sensitive_headers": GetSensitiveHeaderSchema(),
+			"enveloped":         GetEnvelopedSchema(),
+			"format":            GetFormatSchema(),
 		},
 		"The `ably_rule_amqp_external` resource allows you to create and manage an Ably integration rule for Firehose. Read more at https://ably.com/docs/general/firehose",
-	), nil
+	)
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceRuleAmqpExternal{}
+
+// Checks header names are unique before planning
+func (r resourceRuleAmqpExternal) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateRuleHeaders(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithUpgradeState = resourceRuleAmqpExternal{}
+
+// Upgrades state with headers saved as lists
+func (r resourceRuleAmqpExternal) UpgradeState(ctx context.Context) map[int64]tfsdk_resource.StateUpgrader {
+	return UpgradeRuleHeadersState()
 }
 
 func (r resourceRuleAmqpExternal) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
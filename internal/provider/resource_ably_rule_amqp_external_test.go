@@ -123,9 +123,10 @@ terraform {
 provider "ably" {}
 	  
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_rule_amqp_external" "rule0" {
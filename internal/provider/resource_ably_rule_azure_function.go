@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleAzureFunction struct {
@@ -14,29 +12,48 @@ type resourceRuleAzureFunction struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleAzureFunction) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"headers": GetHeaderSchema(),
-			"azure_app_id": {
-				Type:        types.StringType,
+func (r resourceRuleAzureFunction) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"headers":           GetHeaderSchema(),
+			"sensitive_headers": GetSensitiveHeaderSchema(),
+			"azure_app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The Microsoft Azure Application ID. You can find your Microsoft Azure Application ID",
 			},
-			"function_name": {
-				Type:        types.StringType,
+			"function_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of your Microsoft Azure Function",
 			},
-			"signing_key_id": {
-				Type:        types.StringType,
+			"signing_key_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
+				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. It must be the ID of an enabled key of the same app, such as the id attribute of an ably_api_key resource. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
 			},
 			"format": GetFormatSchema(),
 		},
 		"The `ably_rule_azure_function` resource allows you to create and manage an Ably integration rule for Microsoft Azure Functions. Read more at https://ably.com/docs/general/webhooks/azure",
-	), nil
+	)
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceRuleAzureFunction{}
+
+// Checks header names are unique before planning
+func (r resourceRuleAzureFunction) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateRuleHeaders(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithUpgradeState = resourceRuleAzureFunction{}
+
+// Upgrades state with headers saved as lists
+func (r resourceRuleAzureFunction) UpgradeState(ctx context.Context) map[int64]tfsdk_resource.StateUpgrader {
+	return UpgradeRuleHeadersState()
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceRuleAzureFunction{}
+
+// Checks the signing key belongs to the rule app before planning
+func (r resourceRuleAzureFunction) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	ModifyPlanRuleSigningKey(&r, ctx, req, resp)
 }
 
 func (r resourceRuleAzureFunction) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -50,8 +50,10 @@ func TestAccAblyRuleAzureFunction(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "source.type", "channel.message"),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "request_mode", "batch"),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.function_name", "function0"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.0.name", "User-Agent-Conf"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.0.value", "user-agent-string"),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_rule_azure_function.rule0", "target.headers.*", map[string]string{
+						"name":  "User-Agent-Conf",
+						"value": "user-agent-string",
+					}),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.format", "json"),
 				),
 			},
@@ -73,10 +75,14 @@ func TestAccAblyRuleAzureFunction(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "source.type", "channel.presence"),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "request_mode", "batch"),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.function_name", "function1"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.0.name", "User-Agent-Conf-Update"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.0.value", "user-agent-string-update"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.1.name", "Custom-Header"),
-					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.headers.1.value", "custom-header-string"),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_rule_azure_function.rule0", "target.headers.*", map[string]string{
+						"name":  "User-Agent-Conf-Update",
+						"value": "user-agent-string-update",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_rule_azure_function.rule0", "target.headers.*", map[string]string{
+						"name":  "Custom-Header",
+						"value": "custom-header-string",
+					}),
 					resource.TestCheckResourceAttr("ably_rule_azure_function.rule0", "target.format", "msgpack"),
 				),
 			},
@@ -110,9 +116,10 @@ terraform {
 provider "ably" {}
 	  
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_api_key" "api_key_0" {
@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleHTTP struct {
@@ -14,25 +12,45 @@ type resourceRuleHTTP struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleHTTP) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"headers": GetHeaderSchema(),
-			"url": {
-				Type:        types.StringType,
+func (r resourceRuleHTTP) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"headers":           GetHeaderSchema(),
+			"sensitive_headers": GetSensitiveHeaderSchema(),
+			"url": schema.StringAttribute{
 				Required:    true,
 				Description: "The webhook URL that Ably will POST events to",
 			},
-			"signing_key_id": {
-				Type:        types.StringType,
+			"signing_key_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
+				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. It must be the ID of an enabled key of the same app, such as the id attribute of an ably_api_key resource. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
 			},
 			"format":    GetFormatSchema(),
 			"enveloped": GetEnvelopedSchema(),
 		},
 		"The `ably_rule_http` resource allows you to create and manage an Ably integration rule for HTTP. Read more at https://ably.com/docs/general/webhooks",
-	), nil
+	)
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceRuleHTTP{}
+
+// Checks header names are unique before planning
+func (r resourceRuleHTTP) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateRuleHeaders(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithUpgradeState = resourceRuleHTTP{}
+
+// Upgrades state with headers saved as lists
+func (r resourceRuleHTTP) UpgradeState(ctx context.Context) map[int64]tfsdk_resource.StateUpgrader {
+	return UpgradeRuleHeadersState()
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceRuleHTTP{}
+
+// Checks the signing key belongs to the rule app before planning
+func (r resourceRuleHTTP) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	ModifyPlanRuleSigningKey(&r, ctx, req, resp)
 }
 
 func (r resourceRuleHTTP) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleGoogleFunction struct {
@@ -14,35 +12,53 @@ type resourceRuleGoogleFunction struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleGoogleFunction) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"region": {
-				Type:        types.StringType,
+func (r resourceRuleGoogleFunction) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"region": schema.StringAttribute{
 				Required:    true,
 				Description: "The region in which your Google Cloud Function is hosted. See the Google documentation for more details.",
 			},
-			"function_name": {
-				Type:        types.StringType,
+			"function_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of your Google Cloud Function.",
 			},
-			"project_id": {
-				Type:        types.StringType,
+			"project_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The project ID for your Google Cloud Project that was generated when you created your project.",
 			},
-			"headers": GetHeaderSchema(),
-			"signing_key_id": {
-				Type:        types.StringType,
+			"headers":           GetHeaderSchema(),
+			"sensitive_headers": GetSensitiveHeaderSchema(),
+			"signing_key_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
+				Description: "The signing key ID for use in batch mode. Ably will optionally sign the payload using an API key ensuring your servers can validate the payload using the private API key. It must be the ID of an enabled key of the same app, such as the id attribute of an ably_api_key resource. See the [webhook security docs](https://ably.com/docs/general/webhooks#security) for more information",
 			},
 			"enveloped": GetEnvelopedSchema(),
 			"format":    GetFormatSchema(),
 		},
 		"The `ably_rule_google_cloud_function` resource allows you to create and manage an Ably integration rule for Google cloud functions. Read more at https://ably.com/docs/general/webhooks/google-functions",
-	), nil
+	)
+}
+
+var _ tfsdk_resource.ResourceWithValidateConfig = resourceRuleGoogleFunction{}
+
+// Checks header names are unique before planning
+func (r resourceRuleGoogleFunction) ValidateConfig(ctx context.Context, req tfsdk_resource.ValidateConfigRequest, resp *tfsdk_resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(ValidateRuleHeaders(ctx, req.Config)...)
+}
+
+var _ tfsdk_resource.ResourceWithUpgradeState = resourceRuleGoogleFunction{}
+
+// Upgrades state with headers saved as lists
+func (r resourceRuleGoogleFunction) UpgradeState(ctx context.Context) map[int64]tfsdk_resource.StateUpgrader {
+	return UpgradeRuleHeadersState()
+}
+
+var _ tfsdk_resource.ResourceWithModifyPlan = resourceRuleGoogleFunction{}
+
+// Checks the signing key belongs to the rule app before planning
+func (r resourceRuleGoogleFunction) ModifyPlan(ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	ModifyPlanRuleSigningKey(&r, ctx, req, resp)
 }
 
 func (r resourceRuleGoogleFunction) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -2,6 +2,7 @@ package ably_control
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -28,6 +29,13 @@ func TestAccAblyRuleHTTP(t *testing.T) {
 	}
 	]`
 
+	duplicate_headers_block := `[
+	{
+		name : "authorization",
+		value : "not-secret",
+	},
+	]`
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testAccProviders,
@@ -53,6 +61,11 @@ func TestAccAblyRuleHTTP(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "source.type", "channel.message"),
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "request_mode", "single"),
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.enveloped", "true"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.headers.#", "1"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.sensitive_headers.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("ably_rule_http.rule0", "target.sensitive_headers.*", map[string]string{
+						"name": "Authorization",
+					}),
 				),
 			},
 			// Update and Read testing of ably_app.app0
@@ -76,7 +89,25 @@ func TestAccAblyRuleHTTP(t *testing.T) {
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "source.type", "channel.message"),
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "request_mode", "batch"),
 					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.enveloped", "false"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.headers.#", "2"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "target.sensitive_headers.#", "1"),
+				),
+			},
+			// Header names must be unique, ignoring case, across headers and sensitive headers
+			{
+				Config: testAccAblyRuleHTTPConfig(
+					update_app_name,
+					"enabled",
+					"^my-channel.*",
+					"channel.message",
+					"batch",
+					duplicate_headers_block,
+					"ably_api_key.api_key_1.id",
+					"https://example1.com/webhooks",
+					"msgpack",
+					"false",
 				),
+				ExpectError: regexp.MustCompile("Duplicate Header"),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
@@ -109,9 +140,10 @@ terraform {
 provider "ably" {}
 	  
 resource "ably_app" "app0" {
-	name     = %[1]q
-	status   = "enabled"
-	tls_only = true
+	name                = %[1]q
+	status              = "enabled"
+	tls_only            = true
+	deletion_protection = false
 }
 
 resource "ably_api_key" "api_key_0" {
@@ -144,6 +176,12 @@ resource "ably_rule_http" "rule0" {
 	request_mode = %[5]q
 	target = {
 	  headers = %[6]s
+	  sensitive_headers = [
+		{
+		  name : "Authorization",
+		  value : "Bearer secret-token",
+		},
+	  ]
 	  signing_key_id = %[7]s
 	  url = %[8]q
 	  format = %[9]q
@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleIFTTT struct {
@@ -14,22 +12,21 @@ type resourceRuleIFTTT struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleIFTTT) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"webhook_key": {
-				Type:        types.StringType,
+func (r resourceRuleIFTTT) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"webhook_key": schema.StringAttribute{
 				Required:    true,
+				Sensitive:   true,
 				Description: "The key in the Webhook Service Documentation page of your IFTTT account",
 			},
-			"event_name": {
-				Type:        types.StringType,
+			"event_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The Event name is used to identify the IFTTT applet that will receive the Event, make sure the name matches the name of the IFTTT applet.",
 			},
 		},
 		"The `ably_rule_ifttt` resource allows you to create and manage an Ably integration rule for IFTTT. Read more at https://ably.com/docs/general/webhooks/ifttt",
-	), nil
+	)
 }
 
 func (r resourceRuleIFTTT) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
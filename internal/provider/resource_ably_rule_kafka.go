@@ -3,9 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -14,55 +13,49 @@ type resourceRuleKafka struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleKafka) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"routing_key": {
-				Type:        types.StringType,
+func (r resourceRuleKafka) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"routing_key": schema.StringAttribute{
 				Required:    true,
 				Description: "The Kafka partition key. This is used to determine which partition a message should be routed to, where a topic has been partitioned. routingKey should be in the format topic:key where topic is the topic to publish to, and key is the value to use as the message key",
 			},
 			"enveloped": GetEnvelopedSchema(),
 			"format":    GetFormatSchema(),
-			"brokers": {
-				Type: types.ListType{
-					ElemType: types.StringType,
-				},
+			"brokers": schema.ListAttribute{
+				ElementType: types.StringType,
 				Required:    true,
 				Description: "This is a list of brokers that host your Kafka partitions. Each broker is specified using the format `host`, `host:port` or `ip:port`",
 			},
-			"auth": {
+			"auth": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "The Kafka [authentication mechanism](https://docs.confluent.io/platform/current/kafka/overview-authentication-methods.html)",
-				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-					"sasl": {
+				Attributes: map[string]schema.Attribute{
+					"sasl": schema.SingleNestedAttribute{
 						Optional:    true,
 						Description: "SASL(Simple Authentication Security Layer) / SCRAM (Salted Challenge Response Authentication Mechanism) uses usernames and passwords stored in ZooKeeper. Credentials are created during installation. See documentation on [configuring SCRAM](https://docs.confluent.io/platform/current/kafka/authentication_sasl/authentication_sasl_scram.html#kafka-sasl-auth-scram)",
-						Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-							"mechanism": {
+						Attributes: map[string]schema.Attribute{
+							"mechanism": schema.StringAttribute{
 								Description: "`plain` `scram-sha-256` `scram-sha-512`. The hash type to use. SCRAM supports either SHA-256 or SHA-512 hash functions",
-								Type:        types.StringType,
 								Required:    true,
 							},
-							"username": {
+							"username": schema.StringAttribute{
 								Description: "Kafka login credential",
-								Type:        types.StringType,
 								Required:    true,
 								Sensitive:   true,
 							},
-							"password": {
+							"password": schema.StringAttribute{
 								Description: "Kafka login credential",
-								Type:        types.StringType,
 								Required:    true,
 								Sensitive:   true,
 							},
-						}),
+						},
 					},
-				}),
+				},
 			},
 		},
 		"The `ably_rule_kafka` resource allows you to create and manage an Ably integration rule for Kafka. Read more at https://ably.com/docs/general/firehose/kafka-rule",
-	), nil
+	)
 }
 
 func (r resourceRuleKafka) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
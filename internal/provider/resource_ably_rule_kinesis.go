@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleKinesis struct {
@@ -14,19 +12,16 @@ type resourceRuleKinesis struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleKinesis) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"region": {
-				Type:     types.StringType,
+func (r resourceRuleKinesis) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"region": schema.StringAttribute{
 				Optional: true,
 			},
-			"stream_name": {
-				Type:     types.StringType,
+			"stream_name": schema.StringAttribute{
 				Optional: true,
 			},
-			"partition_key": {
-				Type:     types.StringType,
+			"partition_key": schema.StringAttribute{
 				Optional: true,
 			},
 			"enveloped":      GetEnvelopedSchema(),
@@ -34,7 +29,7 @@ func (r resourceRuleKinesis) GetSchema(_ context.Context) (tfsdk.Schema, diag.Di
 			"authentication": GetAwsAuthSchema(),
 		},
 		"The `ably_rule_kinesis` resource allows you to create and manage an Ably integration rule for AWS Kinesis. Read more at https://ably.com/docs/general/firehose/kinesis-rule",
-	), nil
+	)
 }
 
 func (r resourceRuleKinesis) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleLambda struct {
@@ -14,22 +12,20 @@ type resourceRuleLambda struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleLambda) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"region": {
-				Type:     types.StringType,
+func (r resourceRuleLambda) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"region": schema.StringAttribute{
 				Optional: true,
 			},
-			"function_name": {
-				Type:     types.StringType,
+			"function_name": schema.StringAttribute{
 				Optional: true,
 			},
 			"enveloped":      GetEnvelopedSchema(),
 			"authentication": GetAwsAuthSchema(),
 		},
 		"The `ably_rule_lambda` resource allows you to create and manage an Ably integration rule for AWS Lambda. Read more at https://ably.com/docs/general/webhooks/aws-lambda",
-	), nil
+	)
 }
 
 func (r resourceRuleLambda) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -3,9 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -14,53 +13,47 @@ type resourceRulePulsar struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRulePulsar) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"routing_key": {
-				Type:        types.StringType,
+func (r resourceRulePulsar) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"routing_key": schema.StringAttribute{
 				Required:    true,
 				Description: "The optional routing key (partition key) used to publish messages. Supports interpolation as described in the [Ably FAQs](https://faqs.ably.com/what-is-the-format-of-the-routingkey-for-an-amqp-or-kinesis-reactor-rule).",
 			},
-			"topic": {
-				Type:        types.StringType,
+			"topic": schema.StringAttribute{
 				Required:    true,
 				Description: "A Pulsar topic. This is a named channel for transmission of messages between producers and consumers. The topic has the form: {persistent|non-persistent}://tenant/namespace/topic",
 			},
-			"service_url": {
-				Type:        types.StringType,
+			"service_url": schema.StringAttribute{
 				Required:    true,
 				Description: "The URL of the Pulsar cluster in the form pulsar://host:port or pulsar+ssl://host:port",
 			},
-			"tls_trust_certs": {
-				Type: types.ListType{
-					ElemType: types.StringType,
-				},
+			"tls_trust_certs": schema.ListAttribute{
+				ElementType: types.StringType,
 				Optional:    true,
 				Sensitive:   true,
 				Description: "All connections to a Pulsar endpoint require TLS. The tls_trust_certs option allows you to configure different or additional trust anchors for those TLS connections. This enables server verification. You can specify an optional list of trusted CA certificates to use to verify the TLS certificate presented by the Pulsar cluster. Each certificate should be encoded in PEM format",
 			},
 			"enveloped": GetEnvelopedSchema(),
 			"format":    GetFormatSchema(),
-			"authentication": {
+			"authentication": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "Pulsar supports authenticating clients using security tokens that are based on JSON Web Tokens.",
-				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-					"mode": {
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{
 						Description: "Authentication mode, in this case JSON Web Token. Use `jwt`",
-						Type:        types.StringType,
 						Required:    true,
 					},
-					"token": {
+					"token": schema.StringAttribute{
 						Description: "The JWT string.`",
-						Type:        types.StringType,
 						Required:    true,
+						Sensitive:   true,
 					},
-				}),
+				},
 			},
 		},
 		"The `ably_rule_pulsar` resource allows you to create and manage an Ably integration rule for Pulsar. Read more at https://ably.com/docs/general/firehose/pulsar-rule",
-	), nil
+	)
 }
 
 func (r resourceRulePulsar) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
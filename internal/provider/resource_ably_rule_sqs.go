@@ -3,10 +3,8 @@ package ably_control
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 type resourceRuleSqs struct {
@@ -14,21 +12,18 @@ type resourceRuleSqs struct {
 }
 
 // Get Rule Resource schema
-func (r resourceRuleSqs) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return GetRuleSchema(
-		map[string]tfsdk.Attribute{
-			"region": {
-				Type:        types.StringType,
+func (r resourceRuleSqs) Schema(_ context.Context, _ tfsdk_resource.SchemaRequest, resp *tfsdk_resource.SchemaResponse) {
+	resp.Schema = GetRuleSchema(
+		map[string]schema.Attribute{
+			"region": schema.StringAttribute{
 				Optional:    true,
 				Description: "The region is which AWS SQS is hosted",
 			},
-			"aws_account_id": {
-				Type:        types.StringType,
+			"aws_account_id": schema.StringAttribute{
 				Optional:    true,
 				Description: "Your AWS account ID",
 			},
-			"queue_name": {
-				Type:        types.StringType,
+			"queue_name": schema.StringAttribute{
 				Optional:    true,
 				Description: "The AWS SQS queue name",
 			},
@@ -37,7 +32,7 @@ func (r resourceRuleSqs) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagno
 			"authentication": GetAwsAuthSchema(),
 		},
 		"The `ably_rule_sqs` resource allows you to create and manage an Ably integration rule for AWS SQS. Read more at https://ably.com/docs/general/firehose/sqs-rule",
-	), nil
+	)
 }
 
 func (r resourceRuleSqs) Metadata(ctx context.Context, req tfsdk_resource.MetadataRequest, resp *tfsdk_resource.MetadataResponse) {
@@ -3,11 +3,16 @@ package ably_control
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfsdk_resource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -76,13 +81,13 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 	case *AblyRuleTargetZapier:
 		target = &ably_control_go.HttpZapierTarget{
 			Url:          t.Url,
-			Headers:      GetHeaders(t.Headers),
+			Headers:      GetHeaders(t.Headers, t.SensitiveHeaders),
 			SigningKeyID: t.SigningKeyId,
 		}
 	case *AblyRuleTargetCloudflareWorker:
 		target = &ably_control_go.HttpCloudfareWorkerTarget{
 			Url:          t.Url,
-			Headers:      GetHeaders(t.Headers),
+			Headers:      GetHeaders(t.Headers, t.SensitiveHeaders),
 			SigningKeyID: t.SigningKeyId,
 		}
 	case *AblyRuleTargetPulsar:
@@ -99,17 +104,9 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 			Format:    t.Format,
 		}
 	case *AblyRuleTargetHTTP:
-		var headers []ably_control_go.Header
-		for _, h := range t.Headers {
-			headers = append(headers, ably_control_go.Header{
-				Name:  h.Name.ValueString(),
-				Value: h.Value.ValueString(),
-			})
-		}
-
 		target = &ably_control_go.HttpTarget{
 			Url:          t.Url,
-			Headers:      headers,
+			Headers:      GetHeaders(t.Headers, t.SensitiveHeaders),
 			SigningKeyID: t.SigningKeyId,
 			Format:       t.Format,
 			Enveloped:    t.Enveloped,
@@ -123,7 +120,7 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 		target = &ably_control_go.HttpAzureFunctionTarget{
 			AzureAppID:        t.AzureAppID,
 			AzureFunctionName: t.AzureFunctionName,
-			Headers:           GetHeaders(t.Headers),
+			Headers:           GetHeaders(t.Headers, t.SensitiveHeaders),
 			SigningKeyID:      t.SigningKeyID,
 			Format:            t.Format,
 		}
@@ -132,7 +129,7 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 			Region:       t.Region,
 			ProjectID:    t.ProjectID,
 			FunctionName: t.FunctionName,
-			Headers:      GetHeaders(t.Headers),
+			Headers:      GetHeaders(t.Headers, t.SensitiveHeaders),
 			SigningKeyID: t.SigningKeyId,
 			Enveloped:    t.Enveloped,
 			Format:       t.Format,
@@ -155,7 +152,7 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 	case *AblyRuleTargetAmqp:
 		target = &ably_control_go.AmqpTarget{
 			QueueID:   t.QueueID,
-			Headers:   GetHeaders(t.Headers),
+			Headers:   GetHeaders(t.Headers, t.SensitiveHeaders),
 			Enveloped: t.Enveloped,
 			Format:    t.Format,
 		}
@@ -167,7 +164,7 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 			MandatoryRoute:     t.MandatoryRoute,
 			PersistentMessages: t.PersistentMessages,
 			MessageTTL:         int(t.MessageTtl.ValueInt64()),
-			Headers:            GetHeaders(t.Headers),
+			Headers:            GetHeaders(t.Headers, t.SensitiveHeaders),
 			Enveloped:          t.Enveloped,
 			Format:             t.Format,
 		}
@@ -186,7 +183,8 @@ func GetPlanRule(plan AblyRule) ably_control_go.NewRule {
 	return rule_values
 }
 
-func GetHeaders(headers []AblyRuleHeaders) []ably_control_go.Header {
+// Merges the headers and sensitive headers of a rule into the headers sent to the Control API.
+func GetHeaders(headers []AblyRuleHeaders, sensitive_headers []AblyRuleHeaders) []ably_control_go.Header {
 	var ret_headers []ably_control_go.Header
 	for _, h := range headers {
 		ret_headers = append(ret_headers, ably_control_go.Header{
@@ -194,6 +192,12 @@ func GetHeaders(headers []AblyRuleHeaders) []ably_control_go.Header {
 			Value: h.Value.ValueString(),
 		})
 	}
+	for _, h := range sensitive_headers {
+		ret_headers = append(ret_headers, ably_control_go.Header{
+			Name:  h.Name.ValueString(),
+			Value: h.Value.ValueString(),
+		})
+	}
 
 	return ret_headers
 }
@@ -276,20 +280,22 @@ func GetRuleResponse(ably_rule *ably_control_go.Rule, plan *AblyRule) AblyRule {
 			Enveloped:    v.Enveloped,
 		}
 	case *ably_control_go.HttpZapierTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetZapier{
-			Url:          v.Url,
-			SigningKeyId: v.SigningKeyID,
-			Headers:      headers,
+			Url:              v.Url,
+			SigningKeyId:     v.SigningKeyID,
+			Headers:          headers,
+			SensitiveHeaders: sensitive_headers,
 		}
 	case *ably_control_go.HttpCloudfareWorkerTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetCloudflareWorker{
-			Url:          v.Url,
-			SigningKeyId: v.SigningKeyID,
-			Headers:      headers,
+			Url:              v.Url,
+			SigningKeyId:     v.SigningKeyID,
+			Headers:          headers,
+			SensitiveHeaders: sensitive_headers,
 		}
 	case *ably_control_go.PulsarTarget:
 		resp_target = &AblyRuleTargetPulsar{
@@ -310,36 +316,39 @@ func GetRuleResponse(ably_rule *ably_control_go.Rule, plan *AblyRule) AblyRule {
 			WebhookKey: v.WebhookKey,
 		}
 	case *ably_control_go.HttpGoogleCloudFunctionTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetGoogleFunction{
-			Region:       v.Region,
-			ProjectID:    v.ProjectID,
-			FunctionName: v.FunctionName,
-			Headers:      headers,
-			SigningKeyId: v.SigningKeyID,
-			Enveloped:    v.Enveloped,
-			Format:       v.Format,
+			Region:           v.Region,
+			ProjectID:        v.ProjectID,
+			FunctionName:     v.FunctionName,
+			Headers:          headers,
+			SensitiveHeaders: sensitive_headers,
+			SigningKeyId:     v.SigningKeyID,
+			Enveloped:        v.Enveloped,
+			Format:           v.Format,
 		}
 	case *ably_control_go.HttpAzureFunctionTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetAzureFunction{
 			AzureAppID:        v.AzureAppID,
 			AzureFunctionName: v.AzureFunctionName,
 			Headers:           headers,
+			SensitiveHeaders:  sensitive_headers,
 			SigningKeyID:      v.SigningKeyID,
 			Format:            v.Format,
 		}
 	case *ably_control_go.HttpTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetHTTP{
-			Url:          v.Url,
-			Headers:      headers,
-			SigningKeyId: v.SigningKeyID,
-			Format:       v.Format,
-			Enveloped:    v.Enveloped,
+			Url:              v.Url,
+			Headers:          headers,
+			SensitiveHeaders: sensitive_headers,
+			SigningKeyId:     v.SigningKeyID,
+			Format:           v.Format,
+			Enveloped:        v.Enveloped,
 		}
 	case *ably_control_go.KafkaTarget:
 		resp_target = &AblyRuleTargetKafka{
@@ -356,16 +365,17 @@ func GetRuleResponse(ably_rule *ably_control_go.Rule, plan *AblyRule) AblyRule {
 			Format:    v.Format,
 		}
 	case *ably_control_go.AmqpTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 
 		resp_target = &AblyRuleTargetAmqp{
-			QueueID:   v.QueueID,
-			Headers:   headers,
-			Enveloped: v.Enveloped,
-			Format:    v.Format,
+			QueueID:          v.QueueID,
+			Headers:          headers,
+			SensitiveHeaders: sensitive_headers,
+			Enveloped:        v.Enveloped,
+			Format:           v.Format,
 		}
 	case *ably_control_go.AmqpExternalTarget:
-		headers := ToHeaders(v)
+		headers, sensitive_headers := ToHeaders(v, GetPlanSensitiveHeaders(plan))
 		ttl := types.Int64Null()
 		if v.MessageTTL != 0 {
 			ttl = types.Int64Value(int64(v.MessageTTL))
@@ -379,6 +389,7 @@ func GetRuleResponse(ably_rule *ably_control_go.Rule, plan *AblyRule) AblyRule {
 			PersistentMessages: v.PersistentMessages,
 			MessageTtl:         ttl,
 			Headers:            headers,
+			SensitiveHeaders:   sensitive_headers,
 			Enveloped:          v.Enveloped,
 			Format:             v.Format,
 		}
@@ -408,136 +419,149 @@ func GetRuleResponse(ably_rule *ably_control_go.Rule, plan *AblyRule) AblyRule {
 	return resp_rule
 }
 
-func GetRuleSchema(target map[string]tfsdk.Attribute, markdown_description string) tfsdk.Schema {
-	return tfsdk.Schema{
+// Schema version of rules with headers. Version 1 changed headers and sensitive headers from lists to sets.
+const ruleHeadersSchemaVersion = 1
+
+func GetRuleSchema(target map[string]schema.Attribute, markdown_description string) schema.Schema {
+	rule_schema := schema.Schema{
 		MarkdownDescription: markdown_description,
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Type:        types.StringType,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The rule ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.UseStateForUnknown(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"app_id": {
-				Type:        types.StringType,
+			"app_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The Ably application ID.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"status": {
-				Type:        types.StringType,
+			"status": schema.StringAttribute{
 				Optional:    true,
 				Description: "The status of the rule. Rules can be enabled or disabled.",
 			},
-			"request_mode": {
-				Type:        types.StringType,
+			"request_mode": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
 				Description: "This is Single Request mode or Batch Request mode. Single Request mode sends each event separately to the endpoint specified by the rule",
-				PlanModifiers: []tfsdk.AttributePlanModifier{
+				PlanModifiers: []planmodifier.String{
 					DefaultAttribute(types.StringValue("single")),
-					tfsdk_resource.UseStateForUnknown(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"source": {
+			"source": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "object (rule_source)",
-				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-					"channel_filter": {
-						Type:     types.StringType,
+				Attributes: map[string]schema.Attribute{
+					"channel_filter": schema.StringAttribute{
 						Optional: true,
 					},
-					"type": {
-						Type:     types.StringType,
+					"type": schema.StringAttribute{
 						Required: true,
 					},
-				}),
+				},
 			},
-			"target": {
+			"target": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "object (rule_source)",
-				Attributes:  tfsdk.SingleNestedAttributes(target),
+				Attributes:  target,
 			},
 		},
 	}
+
+	if _, ok := target["headers"]; ok {
+		rule_schema.Version = ruleHeadersSchemaVersion
+	}
+
+	return rule_schema
 }
 
-func GetAwsAuthSchema() tfsdk.Attribute {
-	return tfsdk.Attribute{
+func GetAwsAuthSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
 		Required:    true,
 		Description: "object (rule_source)",
-		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-			"mode": {
-				Type:     types.StringType,
+		Attributes: map[string]schema.Attribute{
+			"mode": schema.StringAttribute{
 				Required: true,
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk_resource.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 				Description: "Authentication method. Use 'credentials' or 'assumeRole'",
 			},
-			"role_arn": {
-				Type:        types.StringType,
+			"role_arn": schema.StringAttribute{
 				Optional:    true,
 				Description: "If you are using the 'ARN of an assumable role' authentication method, this is your Assume Role ARN",
 			},
-			"access_key_id": {
-				Type:        types.StringType,
+			"access_key_id": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
 				Description: "The AWS key ID for the AWS IAM user",
 			},
-			"secret_access_key": {
-				Type:        types.StringType,
+			"secret_access_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
 				Description: "The AWS secret key for the AWS IAM user",
 			},
-		}),
+		},
 	}
 }
 
-func GetHeaderSchema() tfsdk.Attribute {
-	return tfsdk.Attribute{
+func GetHeaderSchema() schema.Attribute {
+	return schema.SetNestedAttribute{
 		Optional:    true,
-		Description: "If you have additional information to send, you'll need to include the relevant headers",
-		Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-			"name": {
-				Type:        types.StringType,
-				Required:    true,
-				Description: "The name of the header",
-			},
-			"value": {
-				Type:        types.StringType,
-				Required:    true,
-				Description: "The value of the header",
-			},
-		}),
+		Description: "If you have additional information to send, you'll need to include the relevant headers. Header names must be unique, ignoring case",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: getHeaderAttributes(),
+		},
+	}
+}
+
+// Gets the sensitive headers schema. Their values, such as Authorization bearer tokens, are redacted in plan output.
+func GetSensitiveHeaderSchema() schema.Attribute {
+	return schema.SetNestedAttribute{
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Headers with secret values, such as Authorization tokens, which are sent along with headers but redacted in plan output",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: getHeaderAttributes(),
+		},
+	}
+}
+
+func getHeaderAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the header",
+		},
+		"value": schema.StringAttribute{
+			Required:    true,
+			Description: "The value of the header",
+		},
 	}
 }
 
-func GetEnvelopedSchema() tfsdk.Attribute {
-	return tfsdk.Attribute{
-		Type:        types.BoolType,
+func GetEnvelopedSchema() schema.Attribute {
+	return schema.BoolAttribute{
 		Optional:    true,
 		Computed:    true,
 		Description: "Delivered messages are wrapped in an Ably envelope by default that contains metadata about the message and its payload. The form of the envelope depends on whether it is part of a Webhook/Function or a Queue/Firehose rule. For everything besides Webhooks, you can ensure you only get the raw payload by unchecking \"Enveloped\" when setting up the rule.",
-		PlanModifiers: []tfsdk.AttributePlanModifier{
+		PlanModifiers: []planmodifier.Bool{
 			DefaultAttribute(types.BoolValue(false)),
 		},
 	}
 }
 
-func GetFormatSchema() tfsdk.Attribute {
-	return tfsdk.Attribute{
-		Type:        types.StringType,
+func GetFormatSchema() schema.Attribute {
+	return schema.StringAttribute{
 		Optional:    true,
 		Computed:    true,
 		Description: "JSON provides a text-based encoding, whereas MsgPack provides a more efficient binary encoding",
-		PlanModifiers: []tfsdk.AttributePlanModifier{
+		PlanModifiers: []planmodifier.String{
 			DefaultAttribute(types.StringValue("json")),
 		},
 	}
@@ -558,10 +582,138 @@ func GetSourceType(mode ably_control_go.SourceType) ably_control_go.SourceType {
 	}
 }
 
-func ToHeaders(plan ably_control_go.Target) []AblyRuleHeaders {
+// Gets the signing key ID of the rule target in the plan or state.
+func GetPlanSigningKeyId(plan *AblyRule) string {
+	switch t := plan.Target.(type) {
+	case *AblyRuleTargetHTTP:
+		return t.SigningKeyId
+	case *AblyRuleTargetZapier:
+		return t.SigningKeyId
+	case *AblyRuleTargetCloudflareWorker:
+		return t.SigningKeyId
+	case *AblyRuleTargetGoogleFunction:
+		return t.SigningKeyId
+	case *AblyRuleTargetAzureFunction:
+		return t.SigningKeyID
+	}
+
+	return ""
+}
+
+// Checks the signing key ID refers to a key of the rule app which is not revoked.
+func ValidateRuleSigningKey(client *ably_control_go.Client, app_id string, signing_key_id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := path.Root("target").AtName("signing_key_id")
+
+	// Key names are prefixed with the ID of the app the key belongs to
+	if key_app_id, key_id, found := strings.Cut(signing_key_id, "."); found {
+		if key_app_id != app_id {
+			diags.AddAttributeError(
+				p,
+				"Invalid Signing Key",
+				fmt.Sprintf("signing_key_id %q refers to a key of app %q, but the rule belongs to app %q", signing_key_id, key_app_id, app_id),
+			)
+		} else {
+			diags.AddAttributeError(
+				p,
+				"Invalid Signing Key",
+				fmt.Sprintf("signing_key_id must be a key ID such as the id attribute of an ably_api_key resource, got the key name %q, use %q instead", signing_key_id, key_id),
+			)
+		}
+		return diags
+	}
+
+	keys, err := client.Keys(app_id)
+	if err != nil {
+		diags.AddError(
+			"Error reading keys",
+			fmt.Sprintf("Could not read the keys of app %q to check the signing key, unexpected error: %s", app_id, err.Error()),
+		)
+		return diags
+	}
+
+	for _, key := range keys {
+		if key.ID != signing_key_id {
+			continue
+		}
+
+		if key.Status == 1 {
+			diags.AddAttributeError(
+				p,
+				"Invalid Signing Key",
+				fmt.Sprintf("signing_key_id %q refers to a revoked key, payloads can only be signed with an enabled key", signing_key_id),
+			)
+		}
+		return diags
+	}
+
+	diags.AddAttributeError(
+		p,
+		"Invalid Signing Key",
+		fmt.Sprintf("signing_key_id %q is not a key of app %q, it may belong to another app", signing_key_id, app_id),
+	)
+
+	return diags
+}
+
+// Checks the signing key of a rule when planning. Unknown values, such as the ID of a key which is yet to be
+// created, are checked when the rule is applied.
+func ModifyPlanRuleSigningKey(r Rule, ctx context.Context, req tfsdk_resource.ModifyPlanRequest, resp *tfsdk_resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed or the provider is configured with unknown values
+	if req.Plan.Raw.IsNull() || !r.Provider().configured {
+		return
+	}
+
+	var app_id, signing_key_id types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("app_id"), &app_id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("target").AtName("signing_key_id"), &signing_key_id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if app_id.IsUnknown() || signing_key_id.IsUnknown() || signing_key_id.ValueString() == "" {
+		return
+	}
+
+	resp.Diagnostics.Append(ValidateRuleSigningKey(r.Provider().client, app_id.ValueString(), signing_key_id.ValueString())...)
+}
+
+// Gets the sensitive headers of the rule target in the plan or state.
+func GetPlanSensitiveHeaders(plan *AblyRule) []AblyRuleHeaders {
+	switch t := plan.Target.(type) {
+	case *AblyRuleTargetHTTP:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetZapier:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetCloudflareWorker:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetGoogleFunction:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetAzureFunction:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetAmqp:
+		return t.SensitiveHeaders
+	case *AblyRuleTargetAmqpExternal:
+		return t.SensitiveHeaders
+	}
+
+	return nil
+}
+
+// Splits the headers returned by the Control API into headers and sensitive headers.
+// Headers named in the plan sensitive headers are kept out of headers.
+func ToHeaders(plan ably_control_go.Target, plan_sensitive_headers []AblyRuleHeaders) ([]AblyRuleHeaders, []AblyRuleHeaders) {
 	var resp_headers []AblyRuleHeaders
+	var resp_sensitive_headers []AblyRuleHeaders
 	var headers []ably_control_go.Header
 
+	// Header names are matched ignoring case, as the API may not return them in the configured case.
+	// The name from the plan is kept so the case does not cause a diff.
+	sensitive := map[string]types.String{}
+	for _, h := range plan_sensitive_headers {
+		sensitive[strings.ToLower(h.Name.ValueString())] = h.Name
+	}
+
 	switch t := plan.(type) {
 	case *ably_control_go.HttpTarget:
 		headers = t.Headers
@@ -584,10 +736,87 @@ func ToHeaders(plan ably_control_go.Target) []AblyRuleHeaders {
 			Name:  types.StringValue(b.Name),
 			Value: types.StringValue(b.Value),
 		}
-		resp_headers = append(resp_headers, item)
+		if name, ok := sensitive[strings.ToLower(b.Name)]; ok {
+			item.Name = name
+			resp_sensitive_headers = append(resp_sensitive_headers, item)
+		} else {
+			resp_headers = append(resp_headers, item)
+		}
+	}
+
+	sortHeaders(resp_headers)
+	sortHeaders(resp_sensitive_headers)
+
+	return resp_headers, resp_sensitive_headers
+}
+
+// Sorts headers by name and value, so they have the same order whichever order the Control API returns them in.
+func sortHeaders(headers []AblyRuleHeaders) {
+	sort.SliceStable(headers, func(i, j int) bool {
+		if headers[i].Name.ValueString() != headers[j].Name.ValueString() {
+			return headers[i].Name.ValueString() < headers[j].Name.ValueString()
+		}
+		return headers[i].Value.ValueString() < headers[j].Value.ValueString()
+	})
+}
+
+// Checks header names are unique, ignoring case, across the headers and sensitive headers of a rule target.
+func ValidateRuleHeaders(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	names := map[string]bool{}
+	for _, attr := range []string{"headers", "sensitive_headers"} {
+		var headers types.Set
+		diags.Append(config.GetAttribute(ctx, path.Root("target").AtName(attr), &headers)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, v := range headers.Elements() {
+			header, ok := v.(types.Object)
+			if !ok || header.IsUnknown() {
+				continue
+			}
+
+			name, _ := header.Attributes()["name"].(types.String)
+			if name.IsUnknown() || name.IsNull() {
+				continue
+			}
+
+			key := strings.ToLower(name.ValueString())
+			if names[key] {
+				diags.AddAttributeError(
+					path.Root("target").AtName(attr).AtSetValue(header).AtName("name"),
+					"Duplicate Header",
+					fmt.Sprintf("header names must be unique, ignoring case, across headers and sensitive_headers, got %q more than once", name.ValueString()),
+				)
+			}
+			names[key] = true
+		}
 	}
 
-	return resp_headers
+	return diags
+}
+
+// Gets the state upgraders of rules with headers. Headers were lists in version 0 and are sets since version 1.
+// Lists and sets are both stored as JSON arrays, so the prior state is read with the current schema.
+func UpgradeRuleHeadersState() map[int64]tfsdk_resource.StateUpgrader {
+	return map[int64]tfsdk_resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req tfsdk_resource.UpgradeStateRequest, resp *tfsdk_resource.UpgradeStateResponse) {
+				raw, err := req.RawState.Unmarshal(resp.State.Schema.Type().TerraformType(ctx))
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						"Could not read the rule headers saved as lists, unexpected error: "+err.Error(),
+					)
+					return
+				}
+
+				resp.State.Raw = raw
+			},
+		},
+	}
 }
 
 func GetKafkaAuthSchema(headers []AblyRuleHeaders) []ably_control_go.Header {
@@ -627,6 +856,15 @@ func CreateRule[T any](r Rule, ctx context.Context, req tfsdk_resource.CreateReq
 	}
 
 	plan := p.Rule()
+
+	// Checks the signing key again, as it may have been unknown when planning
+	if signing_key_id := GetPlanSigningKeyId(&plan); signing_key_id != "" {
+		resp.Diagnostics.Append(ValidateRuleSigningKey(r.Provider().client, plan.AppID.ValueString(), signing_key_id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	plan_values := GetPlanRule(plan)
 
 	// Creates a new Ably Rule by invoking the CreateRule function from the Client Library
@@ -707,6 +945,14 @@ func UpdateRule[T any](r Rule, ctx context.Context, req tfsdk_resource.UpdateReq
 
 	plan := p.Rule()
 
+	// Checks the signing key again, as it may have been unknown when planning
+	if signing_key_id := GetPlanSigningKeyId(&plan); signing_key_id != "" {
+		resp.Diagnostics.Append(ValidateRuleSigningKey(r.Provider().client, plan.AppID.ValueString(), signing_key_id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	rule_values := GetPlanRule(plan)
 
 	// Gets the Ably App ID and Ably Rule ID value for the resource
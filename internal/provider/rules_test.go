@@ -0,0 +1,70 @@
+package ably_control
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ably_control_go "github.com/ably/ably-control-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateRuleSigningKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apps/app0/keys" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"key0","appId":"app0","status":0},{"id":"key1","appId":"app0","status":1}]`))
+	}))
+	defer server.Close()
+
+	client := &ably_control_go.Client{Url: server.URL}
+
+	cases := []struct {
+		signing_key_id string
+		expected_error string
+	}{
+		{"key0", ""},
+		{"key1", "revoked key"},
+		{"key2", "is not a key of app"},
+		{"app1.key0", `refers to a key of app "app1"`},
+		{"app0.key0", "got the key name"},
+	}
+
+	for _, c := range cases {
+		diags := ValidateRuleSigningKey(client, "app0", c.signing_key_id)
+		if c.expected_error == "" {
+			if diags.HasError() {
+				t.Errorf("unexpected error for %q: %v", c.signing_key_id, diags)
+			}
+			continue
+		}
+
+		if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), c.expected_error) {
+			t.Errorf("expected error containing %q for %q, got: %v", c.expected_error, c.signing_key_id, diags)
+		}
+	}
+}
+
+func TestToHeaders(t *testing.T) {
+	target := &ably_control_go.HttpTarget{
+		Headers: []ably_control_go.Header{
+			{Name: "X-Custom", Value: "custom"},
+			{Name: "AUTHORIZATION", Value: "Bearer secret"},
+		},
+	}
+	plan_sensitive_headers := []AblyRuleHeaders{
+		{Name: types.StringValue("Authorization"), Value: types.StringValue("Bearer secret")},
+	}
+
+	headers, sensitive_headers := ToHeaders(target, plan_sensitive_headers)
+	if len(headers) != 1 || headers[0].Name.ValueString() != "X-Custom" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+	if len(sensitive_headers) != 1 || sensitive_headers[0].Name.ValueString() != "Authorization" || sensitive_headers[0].Value.ValueString() != "Bearer secret" {
+		t.Errorf("unexpected sensitive headers: %v", sensitive_headers)
+	}
+}
@@ -0,0 +1,336 @@
+package ably_control
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Maximum TTL of Ably tokens.
+const tokenMaxTtl = 24 * time.Hour
+
+// Gets the attributes shared by the data sources which sign tokens with an API key.
+func getTokenSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Required:    true,
+			Sensitive:   true,
+			Description: "The complete API key used to sign the token, such as the key attribute of an ably_api_key resource.",
+		},
+		"client_id": schema.StringAttribute{
+			Optional:    true,
+			Description: "The client ID the token is issued to.",
+		},
+		"capabilities": schema.MapAttribute{
+			ElementType: types.SetType{
+				ElemType: types.StringType,
+			},
+			Optional:    true,
+			Description: "The capabilities of the token, which must be a subset of the key capabilities. Defaults to the key capabilities.",
+		},
+		"key_capabilities": schema.MapAttribute{
+			ElementType: types.SetType{
+				ElemType: types.StringType,
+			},
+			Optional:    true,
+			Description: "The capabilities of the key, such as the capabilities attribute of an ably_api_key resource. For a key defined with capability blocks, use `{ for c in ably_api_key.example.capability : c.resource => c.operations }`. Required when capabilities is set, which is checked to be a subset of them.",
+		},
+		"ttl": schema.StringAttribute{
+			Optional:    true,
+			Description: "How long the token is valid for, as a duration such as 30m or 1h. Defaults to 1h, and can be at most 24h.",
+		},
+	}
+}
+
+// Splits an API key into its name and secret.
+func parseAblyKey(key string) (string, string, error) {
+	key_name, key_secret, found := strings.Cut(key, ":")
+	if !found || key_name == "" || key_secret == "" || !strings.Contains(key_name, ".") {
+		return "", "", fmt.Errorf("key must be a complete API key in the format <app_id>.<key_id>:<key_secret>")
+	}
+
+	return key_name, key_secret, nil
+}
+
+// Parses the token TTL, defaulting to an hour.
+func parseTokenTtl(ttl types.String) (time.Duration, error) {
+	if ttl.IsNull() {
+		return time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(ttl.ValueString())
+	if err != nil || d <= 0 || d > tokenMaxTtl {
+		return 0, fmt.Errorf("ttl must be a positive duration of at most %s such as 30m or 1h, got: %q", tokenMaxTtl, ttl.ValueString())
+	}
+
+	return d, nil
+}
+
+// Checks whether a key capability resource matches a requested resource. A key resource of * matches
+// all unqualified channels, [*]* matches all resources and name:* matches names with the given prefix.
+func capabilityResourceMatches(pattern string, resource string) bool {
+	if pattern == resource || pattern == "[*]*" {
+		return true
+	}
+
+	pattern_qualifier, pattern_name := splitCapabilityResource(pattern)
+	qualifier, name := splitCapabilityResource(resource)
+	if pattern_qualifier != qualifier && pattern_qualifier != "*" {
+		return false
+	}
+
+	if pattern_name == "*" {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern_name, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+
+	return pattern_name == name
+}
+
+// Splits a capability resource into its qualifier, such as queue in [queue]name, and name.
+func splitCapabilityResource(resource string) (string, string) {
+	if strings.HasPrefix(resource, "[") {
+		if qualifier, name, found := strings.Cut(resource[1:], "]"); found {
+			return qualifier, name
+		}
+	}
+
+	return "", resource
+}
+
+// Checks the requested capabilities are a subset of the key capabilities.
+func checkCapabilitySubset(requested map[string][]string, key map[string][]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for resource, ops := range requested {
+		for _, op := range ops {
+			granted := false
+			for pattern, key_ops := range key {
+				if !capabilityResourceMatches(pattern, resource) {
+					continue
+				}
+				for _, key_op := range key_ops {
+					if key_op == "*" || key_op == op {
+						granted = true
+					}
+				}
+			}
+
+			if !granted {
+				diags.AddAttributeError(
+					path.Root("capabilities").AtMapKey(resource),
+					"Capability not granted by key",
+					fmt.Sprintf("The key does not grant the %q operation on %q, so it can not be included in the token capabilities.", op, resource),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// Encodes capabilities as the JSON string used in tokens, with resources and operations sorted.
+func capabilityJSON(capabilities map[string][]string) (string, error) {
+	sorted := make(map[string][]string, len(capabilities))
+	for resource, ops := range capabilities {
+		ops = append([]string{}, ops...)
+		sort.Strings(ops)
+		sorted[resource] = ops
+	}
+
+	b, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Common token parameters read from the config of the token data sources.
+type tokenParams struct {
+	KeyName    string
+	KeySecret  string
+	ClientID   string
+	Capability string
+	Ttl        time.Duration
+}
+
+// Reads and validates the shared token attributes.
+func getTokenParams(ctx context.Context, config tfsdk.Config) (tokenParams, diag.Diagnostics) {
+	var params tokenParams
+	var diags diag.Diagnostics
+
+	var key, client_id, ttl types.String
+	var capabilities, key_capabilities map[string][]string
+	diags.Append(config.GetAttribute(ctx, path.Root("key"), &key)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("client_id"), &client_id)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("capabilities"), &capabilities)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("key_capabilities"), &key_capabilities)...)
+	if diags.HasError() {
+		return params, diags
+	}
+
+	key_name, key_secret, err := parseAblyKey(key.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("key"), "Invalid API key", err.Error())
+		return params, diags
+	}
+
+	d, err := parseTokenTtl(ttl)
+	if err != nil {
+		diags.AddAttributeError(path.Root("ttl"), "Invalid Attribute Value", err.Error())
+		return params, diags
+	}
+
+	if capabilities != nil && key_capabilities == nil {
+		diags.AddAttributeError(
+			path.Root("key_capabilities"),
+			"Missing Attribute Value",
+			"key_capabilities must be set when capabilities is set, so that the token capabilities can be checked against the key.",
+		)
+		return params, diags
+	}
+
+	diags.Append(checkCapabilitySubset(capabilities, key_capabilities)...)
+	if diags.HasError() {
+		return params, diags
+	}
+
+	var capability string
+	if capabilities != nil {
+		capability, err = capabilityJSON(capabilities)
+		if err != nil {
+			diags.AddAttributeError(path.Root("capabilities"), "Invalid Attribute Value", err.Error())
+			return params, diags
+		}
+	}
+
+	return tokenParams{
+		KeyName:    key_name,
+		KeySecret:  key_secret,
+		ClientID:   client_id.ValueString(),
+		Capability: capability,
+		Ttl:        d,
+	}, diags
+}
+
+// Checks whether a JWT claim is set by signAblyJwt, so can not be set as an extra claim.
+func isReservedJwtClaim(claim string) bool {
+	return claim == "iat" || claim == "exp" || strings.HasPrefix(claim, "x-ably-")
+}
+
+// Checks the extra JWT claims do not include reserved claims.
+func validateJwtClaims(claims map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for claim := range claims {
+		if isReservedJwtClaim(claim) {
+			diags.AddAttributeError(
+				path.Root("claims").AtMapKey(claim),
+				"Invalid Attribute Value",
+				fmt.Sprintf("claims can not include iat, exp or claims starting with x-ably-, which are set from the other attributes, got: %q", claim),
+			)
+		}
+	}
+
+	return diags
+}
+
+// Signs an Ably JWT with the key secret. Reserved claims in the extra claims are ignored, so
+// they can not override the Ably claims.
+func signAblyJwt(params tokenParams, claims map[string]string, issued_at time.Time) (string, error) {
+	header := map[string]string{
+		"typ": "JWT",
+		"alg": "HS256",
+		"kid": params.KeyName,
+	}
+
+	payload := map[string]interface{}{}
+	for k, v := range claims {
+		if !isReservedJwtClaim(k) {
+			payload[k] = v
+		}
+	}
+	payload["iat"] = issued_at.Unix()
+	payload["exp"] = issued_at.Add(params.Ttl).Unix()
+	if params.Capability != "" {
+		payload["x-ably-capability"] = params.Capability
+	}
+	if params.ClientID != "" {
+		payload["x-ably-clientId"] = params.ClientID
+	}
+
+	header_json, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	payload_json, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signing_input := base64.RawURLEncoding.EncodeToString(header_json) + "." + base64.RawURLEncoding.EncodeToString(payload_json)
+	mac := hmac.New(sha256.New, []byte(params.KeySecret))
+	mac.Write([]byte(signing_input))
+
+	return signing_input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Ably TokenRequest, as sent to the Ably REST API to obtain a token.
+type ablyTokenRequest struct {
+	KeyName    string `json:"keyName"`
+	Ttl        int64  `json:"ttl"`
+	Capability string `json:"capability,omitempty"`
+	ClientID   string `json:"clientId,omitempty"`
+	Timestamp  int64  `json:"timestamp"`
+	Nonce      string `json:"nonce"`
+	Mac        string `json:"mac"`
+}
+
+// Creates a TokenRequest signed with the key secret.
+func signAblyTokenRequest(params tokenParams, timestamp time.Time, nonce string) ablyTokenRequest {
+	token_request := ablyTokenRequest{
+		KeyName:    params.KeyName,
+		Ttl:        params.Ttl.Milliseconds(),
+		Capability: params.Capability,
+		ClientID:   params.ClientID,
+		Timestamp:  timestamp.UnixMilli(),
+		Nonce:      nonce,
+	}
+
+	signing_input := fmt.Sprintf("%s\n%d\n%s\n%s\n%d\n%s\n",
+		token_request.KeyName, token_request.Ttl, token_request.Capability, token_request.ClientID, token_request.Timestamp, token_request.Nonce)
+	mac := hmac.New(sha256.New, []byte(params.KeySecret))
+	mac.Write([]byte(signing_input))
+	token_request.Mac = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return token_request
+}
+
+// Generates a random nonce for a TokenRequest.
+func tokenNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,127 @@
+package ably_control
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckCapabilitySubset(t *testing.T) {
+	key := map[string][]string{
+		"chat:*":   {"publish", "subscribe"},
+		"status":   {"*"},
+		"[queue]*": {"subscribe"},
+	}
+
+	cases := []struct {
+		requested map[string][]string
+		expected  bool
+	}{
+		{map[string][]string{"chat:room1": {"publish"}}, true},
+		{map[string][]string{"chat:*": {"subscribe"}}, true},
+		{map[string][]string{"status": {"presence", "history"}}, true},
+		{map[string][]string{"[queue]appid:name": {"subscribe"}}, true},
+		{map[string][]string{"chat:room1": {"presence"}}, false},
+		{map[string][]string{"other": {"publish"}}, false},
+		{map[string][]string{"*": {"publish"}}, false},
+		{map[string][]string{"[queue]appid:name": {"publish"}}, false},
+	}
+
+	for _, c := range cases {
+		diags := checkCapabilitySubset(c.requested, key)
+		if diags.HasError() == c.expected {
+			t.Errorf("checkCapabilitySubset(%v) errors = %t, expected subset %t", c.requested, diags.HasError(), c.expected)
+		}
+	}
+
+	if diags := checkCapabilitySubset(map[string][]string{"[meta]connection": {"subscribe"}}, map[string][]string{"[*]*": {"*"}}); diags.HasError() {
+		t.Errorf("expected [*]* to grant all resources, got: %v", diags)
+	}
+}
+
+func TestSignAblyJwt(t *testing.T) {
+	params := tokenParams{
+		KeyName:    "appid.keyid",
+		KeySecret:  "secret",
+		ClientID:   "bob",
+		Capability: `{"chat:*":["publish"]}`,
+		Ttl:        time.Hour,
+	}
+	issued_at := time.Unix(1700000000, 0)
+
+	token, err := signAblyJwt(params, map[string]string{"role": "tester", "exp": "0", "x-ably-capability": `{"*":["*"]}`}, issued_at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected JWT with 3 parts, got: %s", token)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Error("JWT signature does not match")
+	}
+
+	var header map[string]string
+	header_json, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	if err := json.Unmarshal(header_json, &header); err != nil || header["kid"] != "appid.keyid" || header["alg"] != "HS256" {
+		t.Errorf("unexpected JWT header: %s", header_json)
+	}
+
+	var claims map[string]interface{}
+	claims_json, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if err := json.Unmarshal(claims_json, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims["exp"] != float64(1700003600) || claims["iat"] != float64(1700000000) {
+		t.Errorf("unexpected JWT times: %s", claims_json)
+	}
+	if claims["x-ably-clientId"] != "bob" || claims["x-ably-capability"] != params.Capability || claims["role"] != "tester" {
+		t.Errorf("unexpected JWT claims: %s", claims_json)
+	}
+}
+
+func TestValidateJwtClaims(t *testing.T) {
+	if diags := validateJwtClaims(map[string]string{"role": "tester", "ably": "x"}); diags.HasError() {
+		t.Errorf("expected no errors, got: %v", diags)
+	}
+
+	for _, claim := range []string{"iat", "exp", "x-ably-capability", "x-ably-clientId", "x-ably-other"} {
+		if diags := validateJwtClaims(map[string]string{claim: "x"}); !diags.HasError() {
+			t.Errorf("expected an error for claim %q", claim)
+		}
+	}
+}
+
+func TestSignAblyTokenRequest(t *testing.T) {
+	capability, err := capabilityJSON(map[string][]string{"chat:*": {"subscribe", "publish"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := tokenParams{
+		KeyName:    "appid.keyid",
+		KeySecret:  "secret",
+		ClientID:   "bob",
+		Capability: capability,
+		Ttl:        time.Hour,
+	}
+
+	token_request := signAblyTokenRequest(params, time.UnixMilli(1700000000000), "abc123")
+	if token_request.Capability != `{"chat:*":["publish","subscribe"]}` {
+		t.Errorf("unexpected capability: %s", token_request.Capability)
+	}
+	if token_request.Ttl != 3600000 {
+		t.Errorf("unexpected ttl: %d", token_request.Ttl)
+	}
+	if token_request.Mac != "lc3ewqVTtbNmPfx2yGbVFTmgoa48gFG5UPVqTsxLoPg=" {
+		t.Errorf("unexpected mac: %s", token_request.Mac)
+	}
+}